@@ -0,0 +1,99 @@
+package gracious
+
+// The operations in this file each return a new QualitativeSignal and never modify Q or their arguments, so they can
+// be chained into pipelines without the results sharing a Features map.
+
+// Intersect returns the features which are active in both Q and the other signal. Each feature takes the smaller of
+// its two values.
+func (q QualitativeSignal) Intersect(other QualitativeSignal) QualitativeSignal {
+	result := q.derive()
+	for addr, feature := range q.Features {
+		if value, ok := other.Features[addr]; ok {
+			if value < feature {
+				feature = value
+			}
+			result.Features[addr] = feature
+		}
+	}
+	return result
+}
+
+// Difference returns the features of Q which are not active in the other signal.
+func (q QualitativeSignal) Difference(other QualitativeSignal) QualitativeSignal {
+	result := q.derive()
+	for addr, feature := range q.Features {
+		if _, ok := other.Features[addr]; !ok {
+			result.Features[addr] = feature
+		}
+	}
+	return result
+}
+
+// Mask returns the features of Q which lie under the support of the mask, that is, the features of Q at Addresses
+// which are active in the mask. Unlike Intersect, the features keep the values they have in Q.
+func (q QualitativeSignal) Mask(mask QualitativeSignal) QualitativeSignal {
+	result := q.derive()
+	for addr, feature := range q.Features {
+		if _, ok := mask.Features[addr]; ok {
+			result.Features[addr] = feature
+		}
+	}
+	return result
+}
+
+// Threshold returns the features of Q whose values are at least level.
+func (q QualitativeSignal) Threshold(level int) QualitativeSignal {
+	result := q.derive()
+	for addr, feature := range q.Features {
+		if feature >= level {
+			result.Features[addr] = feature
+		}
+	}
+	return result
+}
+
+// Clamp returns the features of Q with every value limited to the range from low to high. Features which are clamped
+// to 0 are removed, as the absence of a feature already signifies 0.
+func (q QualitativeSignal) Clamp(low, high int) QualitativeSignal {
+	result := q.derive()
+	for addr, feature := range q.Features {
+		if feature < low {
+			feature = low
+		}
+		if feature > high {
+			feature = high
+		}
+		if feature != 0 {
+			result.Features[addr] = feature
+		}
+	}
+	return result
+}
+
+// Translate returns the features of Q with every Address moved by the offset. An offset with a Z moves the features
+// to another layer.
+func (q QualitativeSignal) Translate(offset Address) QualitativeSignal {
+	result := q.derive()
+	for addr, feature := range q.Features {
+		result.Features[Address{X: addr.X + offset.X, Y: addr.Y + offset.Y, Z: addr.Z + offset.Z}] = feature
+	}
+	return result
+}
+
+// Crop returns the features of Q which lie within the bounding box that begins at origin and spans width columns
+// along X and height rows along Y, as used by the DenseSignal. Only features on the layer of the origin are kept.
+func (q QualitativeSignal) Crop(origin Address, width, height int) QualitativeSignal {
+	result := q.derive()
+	for addr, feature := range q.Features {
+		x, y := addr.X-origin.X, addr.Y-origin.Y
+		if addr.Z == origin.Z && x >= 0 && y >= 0 && x < width && y < height {
+			result.Features[addr] = feature
+		}
+	}
+	return result
+}
+
+// derive returns a new QualitativeSignal with the identity of Q and no features.
+func (q QualitativeSignal) derive() QualitativeSignal {
+	return QualitativeSignal{Id: q.Id, Novelty: q.Novelty, MisMatch: q.MisMatch, Features: make(map[Address]int)}
+}
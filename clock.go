@@ -0,0 +1,208 @@
+package gracious
+
+import (
+	"sync"
+)
+
+// A Clocked component takes part in the two-phase tick of a Clock. During the first phase every component samples
+// the signals published during the previous tick and computes its outputs, without making them visible. During the
+// second phase every component publishes the outputs it computed. Because no component can observe another's output
+// from the same tick, the result of a tick is independent of the order in which the components are evaluated.
+type Clocked interface {
+	Sample(tick uint64)  // Reads the inputs published at tick-1 and computes the outputs for tick
+	Publish(tick uint64) // Makes the outputs computed for tick visible
+}
+
+// A Publisher is any component whose output can be read by a Clocked component during the sample phase.
+type Publisher interface {
+	Published() QualitativeSignal
+}
+
+// A Clock drives a set of Clocked components through synchronous two-phase ticks. The components sample in parallel,
+// and then publish in parallel, so that networks with feedback loops evaluate deterministically regardless of goroutine
+// scheduling or the order the components were registered in.
+type Clock struct {
+	mu         sync.Mutex
+	tick       uint64
+	components []Clocked
+}
+
+// NewClock returns a new Clock at tick 0 with no components.
+func NewClock() *Clock {
+	c := Clock{}
+	return &c
+}
+
+// Register adds components to the Clock. They take part in every following tick.
+func (c *Clock) Register(components ...Clocked) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.components = append(c.components, components...)
+}
+
+// Now returns the latest tick of the Clock.
+func (c *Clock) Now() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tick
+}
+
+// Tick advances the Clock by one tick. Every component samples, and once every component has sampled, every
+// component publishes. The new tick is returned.
+func (c *Clock) Tick() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tick++
+	var wg sync.WaitGroup
+	for _, component := range c.components {
+		wg.Add(1)
+		go func(component Clocked) {
+			defer wg.Done()
+			component.Sample(c.tick)
+		}(component)
+	}
+	wg.Wait()
+	for _, component := range c.components {
+		wg.Add(1)
+		go func(component Clocked) {
+			defer wg.Done()
+			component.Publish(c.tick)
+		}(component)
+	}
+	wg.Wait()
+	return c.tick
+}
+
+// A Latch holds a QualitativeSignal across a tick. A signal written to the Latch is pending until the Latch is
+// published, so components reading the Latch during the sample phase always see the signal of the previous tick.
+type Latch struct {
+	mu        sync.RWMutex
+	id        string
+	published QualitativeSignal
+	pending   QualitativeSignal
+}
+
+// NewLatch returns a new Latch holding an empty signal.
+func NewLatch(id string) *Latch {
+	l := Latch{id: id, published: NewQualitativeSignal(id), pending: NewQualitativeSignal(id)}
+	return &l
+}
+
+// GetId returns the id of this Latch
+func (l *Latch) GetId() string {
+	return l.id
+}
+
+// Write sets the signal to publish at the end of the current tick.
+func (l *Latch) Write(signal QualitativeSignal) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pending = signal
+}
+
+// Published returns a copy of the signal published during the latest tick.
+func (l *Latch) Published() QualitativeSignal {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.published.Clone()
+}
+
+// Sample does nothing, as a Latch has no inputs.
+func (l *Latch) Sample(tick uint64) {}
+
+// Publish makes the pending signal the published signal.
+func (l *Latch) Publish(tick uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.published = l.pending
+}
+
+// A ClockedGroup evokes a Group once each tick of a Clock. The inputs of the ClockedGroup are the Publishers it is
+// connected to, and its output is the firing pattern of the Group, published at the end of the tick.
+type ClockedGroup struct {
+	Group                   // The evoked Group
+	main        []Publisher // The Publishers composited into the main signal
+	association []Publisher // The Publishers composited into the association signal
+	gate        []Publisher // The Publishers composited into the learning gate signal
+	modulation  []Publisher // The Publishers composited into the modulation signal
+	output      *Latch
+}
+
+// NewClockedGroup returns a new ClockedGroup for the Group with no inputs.
+func NewClockedGroup(g Group) *ClockedGroup {
+	c := ClockedGroup{Group: g, output: NewLatch(g.GetId())}
+	return &c
+}
+
+// Connect feeds the signal published by the input into the port of the Group. Inputs must be connected before the
+// ClockedGroup is registered with a Clock. Inputs of the GatePort are ignored unless the Group is a GatedGroup, and
+// inputs of the ModulationPort unless it is a ModulatedGroup.
+func (c *ClockedGroup) Connect(input Publisher, port Port) {
+	switch port {
+	case MainPort:
+		c.main = append(c.main, input)
+	case GatePort:
+		c.gate = append(c.gate, input)
+	case ModulationPort:
+		c.modulation = append(c.modulation, input)
+	default:
+		c.association = append(c.association, input)
+	}
+}
+
+// Sample evokes the Group with the signals its inputs published during the previous tick.
+func (c *ClockedGroup) Sample(tick uint64) {
+	main := NewQualitativeSignal(c.GetId() + "-main")
+	for _, input := range c.main {
+		main.Composite(input.Published())
+	}
+	association := NewQualitativeSignal(c.GetId() + "-association")
+	for _, input := range c.association {
+		association.Composite(input.Published())
+	}
+	var gate, modulation *QualitativeSignal
+	for _, input := range c.gate {
+		gate = compositeInto(gate, c.GetId()+"-gate", input.Published())
+	}
+	for _, input := range c.modulation {
+		modulation = compositeInto(modulation, c.GetId()+"-modulation", input.Published())
+	}
+	c.output.Write(evokeGroup(c.Group, main, association, gate, modulation))
+}
+
+// Publish publishes the firing pattern of the Group from this tick.
+func (c *ClockedGroup) Publish(tick uint64) {
+	c.output.Publish(tick)
+}
+
+// Published returns the firing pattern of the Group published during the latest tick.
+func (c *ClockedGroup) Published() QualitativeSignal {
+	return c.output.Published()
+}
+
+// A ClockedSource evokes a Source once each tick of a Clock and publishes its signal at the end of the tick.
+type ClockedSource struct {
+	Source // The evoked Source
+	output *Latch
+}
+
+// NewClockedSource returns a new ClockedSource for the Source.
+func NewClockedSource(s Source) *ClockedSource {
+	c := ClockedSource{Source: s, output: NewLatch(s.GetId())}
+	return &c
+}
+
+// Sample evokes the Source.
+func (c *ClockedSource) Sample(tick uint64) {
+	c.output.Write(c.Evoke())
+}
+
+// Publish publishes the signal of the Source from this tick.
+func (c *ClockedSource) Publish(tick uint64) {
+	c.output.Publish(tick)
+}
+
+// Published returns the signal of the Source published during the latest tick.
+func (c *ClockedSource) Published() QualitativeSignal {
+	return c.output.Published()
+}
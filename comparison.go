@@ -0,0 +1,63 @@
+package gracious
+
+import (
+	"math"
+)
+
+// Overlap returns the number of Addresses which are active in both Q and the other signal.
+func (q QualitativeSignal) Overlap(other QualitativeSignal) int {
+	small, large := q.Features, other.Features
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	shared := 0
+	for addr := range small {
+		if _, ok := large[addr]; ok {
+			shared++
+		}
+	}
+	return shared
+}
+
+// HammingDistance returns the number of Addresses which are active in exactly one of Q and the other signal. Feature
+// values are not considered, only whether an Address is active.
+func (q QualitativeSignal) HammingDistance(other QualitativeSignal) int {
+	return len(q.Features) + len(other.Features) - 2*q.Overlap(other)
+}
+
+// Jaccard returns the number of Addresses active in both Q and the other signal over the number of Addresses active
+// in either signal. The result lies between 0 for disjoint signals and 1 for signals with the same active Addresses.
+// Two signals with no features score 0.
+func (q QualitativeSignal) Jaccard(other QualitativeSignal) float64 {
+	shared := q.Overlap(other)
+	union := len(q.Features) + len(other.Features) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// Dot returns the dot product of Q and the other signal, where each shared Address contributes the product of its
+// feature values. Unlike Overlap, stronger features weigh more heavily in the result.
+func (q QualitativeSignal) Dot(other QualitativeSignal) int {
+	small, large := q.Features, other.Features
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	dot := 0
+	for addr, feature := range small {
+		dot += feature * large[addr]
+	}
+	return dot
+}
+
+// Cosine returns the cosine of the angle between Q and the other signal, weighting each Address by its feature value.
+// The result is 1 for signals whose features are in proportion, and 0 for disjoint signals or if either signal has
+// no features.
+func (q QualitativeSignal) Cosine(other QualitativeSignal) float64 {
+	norm := q.Dot(q) * other.Dot(other)
+	if norm == 0 {
+		return 0
+	}
+	return float64(q.Dot(other)) / math.Sqrt(float64(norm))
+}
@@ -0,0 +1,142 @@
+package gracious
+
+import (
+	"sort"
+)
+
+// A Competition forces the features of a QualitativeSignal to compete, so that only the winning features remain in
+// the signal. Compete returns the result as a new QualitativeSignal and leaves its argument unchanged. A BasicGroup
+// applies its Competition to the firing pattern after every evocation, so the Competition can be matched to the
+// topology of the signals the group produces.
+type Competition interface {
+	Compete(q QualitativeSignal) QualitativeSignal
+}
+
+// MaxGap is the classic competition of QualitativeSignal.WinnerTakesAll. Only features within Gap of the strongest
+// feature survive, and surviving features of 4 or more are halved.
+type MaxGap struct {
+	Gap int // Determines how far below the strongest feature a feature may be and still survive
+}
+
+// Compete applies WinnerTakesAll with the Gap to a copy of the signal.
+func (c MaxGap) Compete(q QualitativeSignal) QualitativeSignal {
+	result := q.Clone()
+	result.WinnerTakesAll(c.Gap)
+	return result
+}
+
+// TopK lets the K strongest features survive. Features which tie with the Kth strongest feature also survive, so the
+// result never depends on the order of the Features map.
+type TopK struct {
+	K int // Determines the number of features that survive
+}
+
+// Compete keeps the K strongest features of the signal.
+func (c TopK) Compete(q QualitativeSignal) QualitativeSignal {
+	if c.K <= 0 {
+		return q.derive()
+	}
+	values := sortedValues(q)
+	if c.K >= len(values) {
+		return q.Clone()
+	}
+	return q.Threshold(values[len(values)-c.K])
+}
+
+// Percentile lets the features at or above the Percent percentile of the feature values survive. A Percent of 75
+// keeps roughly the strongest quarter of the features, while a Percent of 0 keeps every feature.
+type Percentile struct {
+	Percent int // Determines the percentile, from 0 to 100, a feature must reach to survive
+}
+
+// Compete keeps the features of the signal at or above the percentile.
+func (c Percentile) Compete(q QualitativeSignal) QualitativeSignal {
+	values := sortedValues(q)
+	if len(values) == 0 {
+		return q.derive()
+	}
+	percent := c.Percent
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	return q.Threshold(values[(len(values)-1)*percent/100])
+}
+
+// PerColumn holds a separate competition within each column of the signal, that is, among the features sharing an
+// X position on the same layer. This suits signals where each column encodes one choice, such as the letter at each
+// position of a word.
+type PerColumn struct {
+	Gap int // Determines how far below the strongest feature of its column a feature may be and still survive
+}
+
+// Compete keeps the strongest features of each column.
+func (c PerColumn) Compete(q QualitativeSignal) QualitativeSignal {
+	return competeWithin(q, c.Gap, func(addr Address) Address { return Address{X: addr.X, Z: addr.Z} })
+}
+
+// PerRow holds a separate competition within each row of the signal, that is, among the features sharing a Y
+// position on the same layer.
+type PerRow struct {
+	Gap int // Determines how far below the strongest feature of its row a feature may be and still survive
+}
+
+// Compete keeps the strongest features of each row.
+func (c PerRow) Compete(q QualitativeSignal) QualitativeSignal {
+	return competeWithin(q, c.Gap, func(addr Address) Address { return Address{Y: addr.Y, Z: addr.Z} })
+}
+
+// SoftNormalize lets every feature survive, but scales the features so that the strongest becomes Scale and the rest
+// keep their proportion to it. Features which scale down to nothing are removed.
+type SoftNormalize struct {
+	Scale int // Determines the value of the strongest feature after normalization
+}
+
+// Compete scales the features of the signal.
+func (c SoftNormalize) Compete(q QualitativeSignal) QualitativeSignal {
+	result := q.derive()
+	max := 0
+	for _, feature := range q.Features {
+		if feature > max {
+			max = feature
+		}
+	}
+	if max <= 0 {
+		return result
+	}
+	for addr, feature := range q.Features {
+		if scaled := (feature*c.Scale + max/2) / max; scaled > 0 {
+			result.Features[addr] = scaled
+		}
+	}
+	return result
+}
+
+// competeWithin keeps the features within gap of the strongest feature sharing the same partition.
+func competeWithin(q QualitativeSignal, gap int, partition func(addr Address) Address) QualitativeSignal {
+	max := make(map[Address]int)
+	for addr, feature := range q.Features {
+		if p := partition(addr); feature > max[p] {
+			max[p] = feature
+		}
+	}
+	result := q.derive()
+	for addr, feature := range q.Features {
+		if feature > 0 && feature >= max[partition(addr)]-gap {
+			result.Features[addr] = feature
+		}
+	}
+	return result
+}
+
+// sortedValues returns the feature values of the signal in ascending order.
+func sortedValues(q QualitativeSignal) []int {
+	values := make([]int, 0, len(q.Features))
+	for _, feature := range q.Features {
+		values = append(values, feature)
+	}
+	sort.Ints(values)
+	return values
+}
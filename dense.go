@@ -0,0 +1,232 @@
+package gracious
+
+import (
+	"math/bits"
+)
+
+// A DenseSignal is an alternate representation of a QualitativeSignal for inputs with a fixed geometry, such as
+// images or letter grids. Rather than a map of active Features, a DenseSignal holds a value for every Address within
+// a known bounding box, along with a bit-vector of the active Addresses. Evaluating a DenseSignal walks the set bits of
+// the bit-vector instead of a hash map, which makes evocation with large association signals considerably faster.
+//
+// The bounding box begins at Origin and spans Width columns along X and Height rows along Y of the layer of the
+// Origin. The bounding box is fixed when the DenseSignal is made. Features outside the bounding box, including those
+// on other layers, can not be represented by the DenseSignal.
+type DenseSignal struct {
+	Id       string  // A descriptive name for this signal. Useful in identification of this signal.
+	Novelty  int     // The sum of all the novelty events in the production of this firing Pattern.
+	MisMatch int     // The sum of all the mismatches in the production of this firing Pattern.
+	origin   Address // The lowest Address in the bounding box of the DenseSignal
+	width    int     // The number of columns, along X, in the bounding box
+	height   int     // The number of rows, along Y, in the bounding box
+	values   []int
+	active   []uint64
+}
+
+// NewDenseSignal returns a new DenseSignal with no active features for the bounding box given by origin, width and
+// height.
+func NewDenseSignal(name string, origin Address, width, height int) DenseSignal {
+	if width < 0 {
+		width = 0
+	}
+	if height < 0 {
+		height = 0
+	}
+	size := width * height
+	return DenseSignal{
+		Id:     name + "-Sig",
+		origin: origin,
+		width:  width,
+		height: height,
+		values: make([]int, size),
+		active: make([]uint64, (size+63)/64),
+	}
+}
+
+// Origin returns the lowest Address in the bounding box of the DenseSignal.
+func (d *DenseSignal) Origin() Address {
+	return d.origin
+}
+
+// Width returns the number of columns, along X, in the bounding box of the DenseSignal.
+func (d *DenseSignal) Width() int {
+	return d.width
+}
+
+// Height returns the number of rows, along Y, in the bounding box of the DenseSignal.
+func (d *DenseSignal) Height() int {
+	return d.height
+}
+
+// Index returns the position of the Address in the DenseSignal. The second return value is false if the Address is
+// outside the bounding box.
+func (d *DenseSignal) Index(addr Address) (int, bool) {
+	x, y := addr.X-d.origin.X, addr.Y-d.origin.Y
+	if addr.Z != d.origin.Z || x < 0 || y < 0 || x >= d.width || y >= d.height {
+		return 0, false
+	}
+	return y*d.width + x, true
+}
+
+// Address returns the Address of the position, i, in the DenseSignal.
+func (d *DenseSignal) Address(i int) Address {
+	return Address{X: d.origin.X + i%d.width, Y: d.origin.Y + i/d.width, Z: d.origin.Z}
+}
+
+// Get returns the value of the feature at the Address. Addresses outside the bounding box are always 0.
+func (d *DenseSignal) Get(addr Address) int {
+	if i, ok := d.Index(addr); ok {
+		return d.values[i]
+	}
+	return 0
+}
+
+// Set sets the value of the feature at the Address. Setting a value of 0 removes the feature. Set returns false if
+// the Address is outside the bounding box, in which case the DenseSignal is unchanged.
+func (d *DenseSignal) Set(addr Address, value int) bool {
+	i, ok := d.Index(addr)
+	if !ok {
+		return false
+	}
+	d.values[i] = value
+	if value != 0 {
+		d.active[i/64] |= 1 << uint(i%64)
+	} else {
+		d.active[i/64] &^= 1 << uint(i%64)
+	}
+	return true
+}
+
+// Len returns the number of active features in the DenseSignal.
+func (d *DenseSignal) Len() int {
+	count := 0
+	for _, word := range d.active {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// ToQualitativeSignal converts the DenseSignal into the sparse QualitativeSignal form.
+func (d *DenseSignal) ToQualitativeSignal() QualitativeSignal {
+	q := QualitativeSignal{Id: d.Id, Novelty: d.Novelty, MisMatch: d.MisMatch, Features: make(map[Address]int)}
+	for w, word := range d.active {
+		for word != 0 {
+			i := w*64 + bits.TrailingZeros64(word)
+			word &= word - 1
+			q.Features[d.Address(i)] = d.values[i]
+		}
+	}
+	return q
+}
+
+// Represent returns a helpful string representation of this DenseSignal.
+func (d *DenseSignal) Represent() string {
+	q := d.ToQualitativeSignal()
+	return q.Represent()
+}
+
+// Bounds returns the smallest bounding box which contains every feature of the QualitativeSignal on the X,Y plane.
+// The origin lies on the lowest layer of the QualitativeSignal. A QualitativeSignal with no features has an empty
+// bounding box.
+func (q QualitativeSignal) Bounds() (origin Address, width, height int) {
+	if len(q.Features) == 0 {
+		return Address{}, 0, 0
+	}
+	first := true
+	var max Address
+	for addr := range q.Features {
+		if first {
+			origin, max = addr, addr
+			first = false
+			continue
+		}
+		if addr.X < origin.X {
+			origin.X = addr.X
+		}
+		if addr.Y < origin.Y {
+			origin.Y = addr.Y
+		}
+		if addr.Z < origin.Z {
+			origin.Z = addr.Z
+		}
+		if addr.X > max.X {
+			max.X = addr.X
+		}
+		if addr.Y > max.Y {
+			max.Y = addr.Y
+		}
+	}
+	return origin, max.X - origin.X + 1, max.Y - origin.Y + 1
+}
+
+// Dense converts the QualitativeSignal into a DenseSignal with the bounding box given by origin, width and height.
+// Features outside the bounding box, or on another layer than the origin, are dropped. Use Bounds to find a bounding
+// box that holds every feature.
+func (q QualitativeSignal) Dense(origin Address, width, height int) DenseSignal {
+	d := NewDenseSignal("", origin, width, height)
+	d.Id, d.Novelty, d.MisMatch = q.Id, q.Novelty, q.MisMatch
+	for addr, feature := range q.Features {
+		d.Set(addr, feature)
+	}
+	return d
+}
+
+// denseSynapses is a neuron's view of its synapses laid out over the bounding box of a DenseSignal. The Synapse
+// pointers are shared with the neuron's synapse map, so training through either view is seen by both.
+type denseSynapses struct {
+	origin   Address
+	width    int
+	height   int
+	synapses []*Synapse
+}
+
+// denseView returns the synapses of the neuron laid out over the bounding box of the DenseSignal, building the view
+// if the neuron has no view or a view of a different bounding box.
+func (n *neuron) denseView(d *DenseSignal) *denseSynapses {
+	if n.dense != nil && n.dense.origin == d.origin && n.dense.width == d.width && n.dense.height == d.height {
+		return n.dense
+	}
+	view := denseSynapses{origin: d.origin, width: d.width, height: d.height, synapses: make([]*Synapse, len(d.values))}
+	for addr, syn := range n.synapses {
+		if i, ok := d.Index(addr); ok {
+			view.synapses[i] = syn
+		}
+	}
+	n.dense = &view
+	return n.dense
+}
+
+// evokeDense behaves exactly as evoke, but evaluates a DenseSignal association through the dense view of the
+// neuron's synapses.
+func (n *neuron) evokeDense(training int, associative *DenseSignal, l learning) {
+	view := n.denseView(associative)
+	sum := 0
+	for w, word := range associative.active {
+		for word != 0 {
+			i := w*64 + bits.TrailingZeros64(word)
+			word &= word - 1
+			if syn := view.synapses[i]; syn != nil {
+				sum += syn.Evoke(associative.values[i])
+			} else if !l.frozen {
+				syn = NewSynapse()
+				view.synapses[i] = syn
+				n.synapses[associative.Address(i)] = syn
+			}
+		}
+	}
+	if n.learningEnabled && !l.frozen {
+		n.learn(sum, training, l, func(visit func(syn *Synapse, association int)) {
+			for w, word := range associative.active {
+				for word != 0 {
+					i := w*64 + bits.TrailingZeros64(word)
+					word &= word - 1
+					visit(view.synapses[i], associative.values[i])
+				}
+			}
+		}, func(addr Address) bool {
+			return associative.Get(addr) != 0
+		})
+	}
+	n.match = ((sum > 0) && (training > 0)) || ((sum <= 0) && (training <= 0))
+	n.axon = sum
+}
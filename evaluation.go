@@ -0,0 +1,95 @@
+package gracious
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// An Evaluation is the strategy a Group uses to evaluate its neurons during evocation. Each neuron only touches its
+// own synapses, so the neurons may be evaluated in any order and in parallel. Which strategy is fastest depends on the
+// number of neurons and on how many association features each neuron must sum. The benchmarks in the tests package
+// compare the strategies over a range of group sizes.
+type Evaluation int
+
+const (
+	// EvaluatePerNeuron starts one goroutine for every neuron. This is the default.
+	EvaluatePerNeuron Evaluation = iota
+	// EvaluateSequential evaluates every neuron in turn on the evoking goroutine. This is the fastest strategy for
+	// small groups, where the cost of starting goroutines outweighs the work of each neuron.
+	EvaluateSequential
+	// EvaluateWorkerPool shares the neurons between a fixed number of worker goroutines. The number of workers is set
+	// by the Workers setting of the Group, and defaults to GOMAXPROCS.
+	EvaluateWorkerPool
+	// EvaluateChunked divides the neurons into one contiguous chunk for each of GOMAXPROCS, and evaluates each chunk
+	// on its own goroutine.
+	EvaluateChunked
+)
+
+// workerBatch is the number of neurons a worker claims from the pool at a time.
+const workerBatch = 64
+
+// evaluate calls evoke once for each index in [0, count) according to the Evaluation strategy. The call returns once
+// every evocation is complete.
+func evaluate(strategy Evaluation, workers int, count int, evoke func(i int)) {
+	switch strategy {
+	case EvaluateSequential:
+		for i := 0; i < count; i++ {
+			evoke(i)
+		}
+	case EvaluateWorkerPool:
+		if workers <= 0 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+		var next int64
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					start := int(atomic.AddInt64(&next, workerBatch)) - workerBatch
+					if start >= count {
+						return
+					}
+					end := start + workerBatch
+					if end > count {
+						end = count
+					}
+					for i := start; i < end; i++ {
+						evoke(i)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	case EvaluateChunked:
+		chunks := runtime.GOMAXPROCS(0)
+		size := (count + chunks - 1) / chunks
+		var wg sync.WaitGroup
+		for start := 0; start < count; start += size {
+			end := start + size
+			if end > count {
+				end = count
+			}
+			wg.Add(1)
+			go func(start, end int) {
+				defer wg.Done()
+				for i := start; i < end; i++ {
+					evoke(i)
+				}
+			}(start, end)
+		}
+		wg.Wait()
+	default:
+		var wg sync.WaitGroup
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				evoke(i)
+			}(i)
+		}
+		wg.Wait()
+	}
+}
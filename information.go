@@ -7,13 +7,20 @@ import (
 
 // An Address is the identifying tag for a location of an object in the neural geometry. It is most importantly
 // used in the mapping the firing patterns, but also has many other applications.
+//
+// The Z component selects a layer of the coordinate plane, so that time slices, channels or modalities can share a
+// single signal without overlapping. Layer 0 is the plain X,Y plane, so code which never sets Z is unaffected by it.
 type Address struct {
 	X int // The x-position on the neural architectures coordinate plane
 	Y int // The y-position on the neural architectures coordinate plane
+	Z int `json:",omitempty"` // The layer of the neural architectures coordinate plane
 }
 
-// Represent returns a helpful string representation of this Address.
+// Represent returns a helpful string representation of this Address. The layer is only shown when it is not 0.
 func (a Address) Represent() string {
+	if a.Z != 0 {
+		return fmt.Sprint("@(", a.X, ",", a.Y, ",", a.Z, ")")
+	}
 	return fmt.Sprint("@(", a.X, ",", a.Y, ")")
 }
 
@@ -40,6 +47,17 @@ func NewQualitativeSignal(name string) QualitativeSignal {
 	return QualitativeSignal{Id: name + "-Sig", Features: make(map[Address]int)}
 }
 
+// Clone returns a copy of the QualitativeSignal with its own Features. A QualitativeSignal is passed by value, but
+// its Features map is shared by every copy, so a signal must be cloned before its Features are modified if the
+// original is still in use elsewhere.
+func (q QualitativeSignal) Clone() QualitativeSignal {
+	clone := QualitativeSignal{Id: q.Id, Novelty: q.Novelty, MisMatch: q.MisMatch, Features: make(map[Address]int, len(q.Features))}
+	for addr, feature := range q.Features {
+		clone.Features[addr] = feature
+	}
+	return clone
+}
+
 // WinnerTakesAll forces the Features in the QualitativeSignal to fight for dominance and only the strongest features
 // will remain present in the signal. The gap parameter permits a level of tolerance for features which almost meet
 // with max threshold. No signal beneath 1 will ever be passed through. Signals with values above 4, will be reduced
@@ -0,0 +1,50 @@
+package gracious
+
+// LateralInhibition sharpens a QualitativeSignal by letting every feature inhibit its neighbors on the X,Y plane of its
+// layer. Each feature is weakened by a share of the value of every feature around it, and features weakened to nothing
+// are removed, so that strong features stand out against their neighborhood and edges between regions are emphasized.
+// Inhibition is computed from the signal as it was before any feature was weakened, so the result does not depend on
+// the order of the Features map.
+//
+// By default each feature inhibits every other feature within Radius columns and rows of it by Strength percent of
+// its value. A Kernel can be given instead to weigh each neighbor by its offset, which may reach into other layers.
+// LateralInhibition is a Competition, so it can sharpen the firing pattern of a BasicGroup, or be applied to a signal
+// before it is used for association.
+type LateralInhibition struct {
+	Radius   int             // Determines how many columns and rows away a feature inhibits its neighbors
+	Strength int             // Determines the percent of its value a feature inhibits each neighbor by
+	Kernel   map[Address]int // Determines the percent each neighbor inhibits by, by offset, replacing Radius and Strength
+}
+
+// Compete returns the signal sharpened by lateral inhibition.
+func (l LateralInhibition) Compete(q QualitativeSignal) QualitativeSignal {
+	kernel := l.kernel()
+	result := q.derive()
+	for addr, feature := range q.Features {
+		inhibition := 0
+		for offset, weight := range kernel {
+			neighbor := Address{X: addr.X - offset.X, Y: addr.Y - offset.Y, Z: addr.Z - offset.Z}
+			inhibition += q.Features[neighbor] * weight
+		}
+		if feature -= inhibition / 100; feature > 0 {
+			result.Features[addr] = feature
+		}
+	}
+	return result
+}
+
+// kernel returns the percent each neighbor inhibits by, by the offset of the inhibited feature from the neighbor.
+func (l LateralInhibition) kernel() map[Address]int {
+	if l.Kernel != nil {
+		return l.Kernel
+	}
+	kernel := make(map[Address]int)
+	for x := -l.Radius; x <= l.Radius; x++ {
+		for y := -l.Radius; y <= l.Radius; y++ {
+			if x != 0 || y != 0 {
+				kernel[Address{X: x, Y: y}] = l.Strength
+			}
+		}
+	}
+	return kernel
+}
@@ -0,0 +1,55 @@
+package io
+
+import (
+	"github.com/Art-of-the-Living/gracious"
+)
+
+// An Actuator is any structure which turns a gracious.QualitativeSignal back into action or data. It is the output
+// counterpart of the Sensor, and satisfies gracious.Output so that Actuators can be added to a gracious.Network. The
+// Actuator interface is implemented by the FunctionalActuator.
+type Actuator interface {
+	GetId() string
+	Actuate(signal gracious.QualitativeSignal)
+}
+
+// A FunctionalActuator acts on the firing patterns of the system. Each QualitativeSignal the FunctionalActuator is
+// actuated with is handed to an externally implemented function, which turns the signal into action or data. This
+// function can be set using SetProcessor. If no function is set, the FunctionalActuator ignores its signals.
+type FunctionalActuator struct {
+	id        string
+	processor func(signal gracious.QualitativeSignal)
+}
+
+// NewFunctionalActuator creates a new FunctionalActuator
+func NewFunctionalActuator(name string, processor func(signal gracious.QualitativeSignal)) *FunctionalActuator {
+	fa := FunctionalActuator{id: name, processor: processor}
+	return &fa
+}
+
+// NewDecodingActuator creates a new FunctionalActuator which decodes each signal it is actuated with into the
+// best-matching JsonSignal of the vocabulary, and hands that JsonSignal to say. Signals which match nothing in the
+// vocabulary are not handed on.
+func NewDecodingActuator(name string, vocabulary JsonSignalArray, say func(word JsonSignal)) *FunctionalActuator {
+	return NewFunctionalActuator(name, func(signal gracious.QualitativeSignal) {
+		if word, ok := vocabulary.BestMatch(signal); ok {
+			say(word)
+		}
+	})
+}
+
+// GetId returns the id of this Actuator
+func (n *FunctionalActuator) GetId() string {
+	return n.id
+}
+
+// SetProcessor sets the function that should be run when this FunctionalActuator component is actuated.
+func (n *FunctionalActuator) SetProcessor(processor func(signal gracious.QualitativeSignal)) {
+	n.processor = processor
+}
+
+// Actuate hands the signal to the processor function. If no processor is defined, the signal is ignored.
+func (n *FunctionalActuator) Actuate(signal gracious.QualitativeSignal) {
+	if n.processor != nil {
+		n.processor(signal)
+	}
+}
@@ -0,0 +1,103 @@
+package io
+
+import (
+	"github.com/Art-of-the-Living/gracious"
+	"sort"
+)
+
+// A Metric determines how a Decoder scores the agreement between an evoked QualitativeSignal and each signal of its
+// vocabulary.
+type Metric int
+
+const (
+	// OverlapMetric scores the number of active features the signals share.
+	OverlapMetric Metric = iota
+	// JaccardMetric scores the number of shared active features over the number of features active in either signal.
+	JaccardMetric
+	// CosineMetric scores the cosine of the angle between the signals, weighting each feature by its value.
+	CosineMetric
+)
+
+// A Label is a single scored candidate of a Decoding. The Confidence of a Label is its share of the total score of
+// every Label in the Decoding.
+type Label struct {
+	Id         string  // The id of the JsonSignal in the vocabulary
+	Score      float64 // The agreement between the evoked signal and the JsonSignal, according to the Metric
+	Confidence float64 // The Score as a fraction of the sum of all scores, between 0 and 1
+}
+
+// A Decoding is the result of decoding an evoked QualitativeSignal. The Labels are ranked from the best match to the
+// worst. A Decoding is Ambiguous when the best Label does not stand apart from the second best.
+type Decoding struct {
+	Labels    []Label
+	Ambiguous bool
+}
+
+// Best returns the best Label of the Decoding. The second return value is false if no Label matches the evoked
+// signal at all, or if the Decoding is Ambiguous.
+func (d Decoding) Best() (Label, bool) {
+	if len(d.Labels) == 0 || d.Labels[0].Score <= 0 || d.Ambiguous {
+		return Label{}, false
+	}
+	return d.Labels[0], true
+}
+
+// A Decoder labels evoked QualitativeSignal values against a vocabulary of known signals, such as a JsonSignalArray
+// read with JsonFromFileName. Where BestMatch only finds the single best JsonSignal, a Decoder scores every JsonSignal
+// of the vocabulary, so that the certainty of a recall can be judged.
+type Decoder struct {
+	ids        []string
+	vocabulary []gracious.QualitativeSignal
+	Metric     Metric  // Determines how the evoked signal is scored against the vocabulary
+	Tolerance  float64 // Determines how close the two best scores must be for a Decoding to be Ambiguous
+}
+
+// NewDecoder creates a new Decoder for the vocabulary which scores with the Metric. The Tolerance of the Decoder is 0,
+// so a Decoding is only Ambiguous when the two best scores are equal.
+func NewDecoder(vocabulary JsonSignalArray, metric Metric) *Decoder {
+	d := Decoder{
+		ids:        make([]string, len(vocabulary.Signals)),
+		vocabulary: vocabulary.ToDistributedSignals(),
+		Metric:     metric,
+	}
+	for i, signal := range vocabulary.Signals {
+		d.ids[i] = signal.Id
+	}
+	return &d
+}
+
+// Decode scores the evoked signal against every signal of the vocabulary and returns the ranked Labels. Labels with
+// equal scores keep the order of the vocabulary.
+func (d *Decoder) Decode(signal gracious.QualitativeSignal) Decoding {
+	decoding := Decoding{Labels: make([]Label, len(d.vocabulary))}
+	total := 0.0
+	for i, known := range d.vocabulary {
+		score := d.score(signal, known)
+		decoding.Labels[i] = Label{Id: d.ids[i], Score: score}
+		total += score
+	}
+	sort.SliceStable(decoding.Labels, func(i, j int) bool {
+		return decoding.Labels[i].Score > decoding.Labels[j].Score
+	})
+	if total > 0 {
+		for i := range decoding.Labels {
+			decoding.Labels[i].Confidence = decoding.Labels[i].Score / total
+		}
+	}
+	if len(decoding.Labels) > 1 && decoding.Labels[0].Score > 0 {
+		decoding.Ambiguous = decoding.Labels[0].Score-decoding.Labels[1].Score <= d.Tolerance
+	}
+	return decoding
+}
+
+// score returns the agreement between the evoked signal and the known signal according to the Metric.
+func (d *Decoder) score(signal, known gracious.QualitativeSignal) float64 {
+	switch d.Metric {
+	case JaccardMetric:
+		return signal.Jaccard(known)
+	case CosineMetric:
+		return signal.Cosine(known)
+	default:
+		return float64(signal.Overlap(known))
+	}
+}
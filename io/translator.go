@@ -36,6 +36,25 @@ func (jsa *JsonSignalArray) GetJsonSignalById(id string) JsonSignal {
 	return js
 }
 
+// BestMatch decodes a QualitativeSignal, such as the firing pattern of a Group, into the JsonSignal of the array
+// which shares the most active features with it. Ties are broken in favour of the JsonSignal with the fewest features
+// that are not shared, and then by the order of the array. The second return value is false if no JsonSignal shares
+// any features with the signal.
+func (jsa *JsonSignalArray) BestMatch(signal gracious.QualitativeSignal) (JsonSignal, bool) {
+	best, bestShared, bestUnshared := -1, 0, 0
+	for i, js := range jsa.Signals {
+		known := js.ToDistributedSignal()
+		shared, unshared := signal.Overlap(known), signal.HammingDistance(known)
+		if shared > bestShared || (shared == bestShared && shared > 0 && unshared < bestUnshared) {
+			best, bestShared, bestUnshared = i, shared, unshared
+		}
+	}
+	if best < 0 {
+		return JsonFromDistributedSignal(gracious.NewQualitativeSignal("void")), false
+	}
+	return jsa.Signals[best], true
+}
+
 // ToDistributedSignals converts a JsonSignalArray into a slice of base.QualitativeSignal values
 func (jsa *JsonSignalArray) ToDistributedSignals() []gracious.QualitativeSignal {
 	var tmp = make([]gracious.QualitativeSignal, len(jsa.Signals))
@@ -85,7 +104,7 @@ type JsonSignal struct {
 func (js JsonSignal) ToDistributedSignal() gracious.QualitativeSignal {
 	tmp := gracious.NewQualitativeSignal(js.Id)
 	for _, feature := range js.Features {
-		tmp.Features[gracious.Address{X: feature.X, Y: feature.Y}] = feature.Value
+		tmp.Features[gracious.Address{X: feature.X, Y: feature.Y, Z: feature.Z}] = feature.Value
 	}
 	return tmp
 }
@@ -95,7 +114,7 @@ func JsonFromDistributedSignal(signal gracious.QualitativeSignal) JsonSignal {
 	tmp := JsonSignal{Features: make([]jsonFeature, len(signal.Features))}
 	i := 0
 	for address, feature := range signal.Features {
-		tmp.Features[i] = jsonFeature{X: address.X, Y: address.Y, Value: feature}
+		tmp.Features[i] = jsonFeature{X: address.X, Y: address.Y, Z: address.Z, Value: feature}
 		i++
 	}
 	return tmp
@@ -105,5 +124,6 @@ func JsonFromDistributedSignal(signal gracious.QualitativeSignal) JsonSignal {
 type jsonFeature struct {
 	X     int `json:"X"`
 	Y     int `json:"Y"`
+	Z     int `json:"Z,omitempty"`
 	Value int `json:"Value"`
 }
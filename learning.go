@@ -10,6 +10,12 @@ import (
 // associated signal pattern. A Group should produce Match, Mismatch, and Novelty
 // patterns depending on the relationship between the main signal and the associative
 // signal at a moment of time, T.
+//
+// Group implementations are safe for use by multiple goroutines. Calls to Evoke
+// and AsyncEvoke on one Group are serialized, so that each evocation observes and
+// trains the neurons as left by the previous one, and the Get methods always
+// report the state after a complete evocation. The exported settings of a Group
+// must be configured before the Group is shared between goroutines.
 type Group interface {
 	GetId() string
 	GetFirePattern() QualitativeSignal
@@ -22,6 +28,22 @@ type Group interface {
 	AsyncEvoke(main, association QualitativeSignal, wg *sync.WaitGroup) QualitativeSignal
 }
 
+// A GatedGroup is a Group whose learning can be gated by a modulating signal. EvokeGated behaves exactly as Evoke,
+// except that the Group only learns when the gate signal holds a positive feature.
+type GatedGroup interface {
+	Group
+	EvokeGated(main, association, gate QualitativeSignal) QualitativeSignal
+}
+
+// A ModulatedGroup is a Group whose learning is modulated by a pleasure and pain signal. EvokeModulated behaves
+// exactly as Evoke, except that the training of the Group is scaled by the level of the modulation signal, the sum of
+// its features. Pleasure, a positive level, reinforces learning, pain, a negative level, punishes the associations
+// which were evoked, and a level of 0 stops the Group from learning.
+type ModulatedGroup interface {
+	Group
+	EvokeModulated(main, association, modulation QualitativeSignal) QualitativeSignal
+}
+
 // BasicGroup is a set of neurons with a specific associative QualitativeSignal
 // type input and a specific main QualitativeSignal type input and output. The
 // BasicGroup controls the learning threshold for the Neurons, as well as the
@@ -30,12 +52,23 @@ type Group interface {
 // 1->N or N->1. A Basic Group cannot associate M->N without interference. For
 // M->N signal association the AdvancedGroup must be used.
 type BasicGroup struct {
+	mu                   sync.RWMutex        // Serializes evocation and guards the neurons and pattern
 	id                   string              // The name of this group of Neurons
 	neurons              map[Address]*neuron // The Neurons which compose this BasicGroup
 	pattern              QualitativeSignal   // The active firing Pattern of this BasicGroup after evocation
+	frozen               bool                // Whether learning is disabled for every neuron
+	learningMask         map[Address]bool    // The Addresses of the neurons which learn, or nil if every neuron learns
 	PassThrough          bool                // Determines if the main signal pattern should pass through to the output
 	WTA                  int                 // Determines if the output of the group should undergo a WTA
+	Competition          Competition         // Determines the competition of the output, replacing WTA when set
 	CorrelationThreshold int                 // Determines the threshold for synaptic learning in this group
+	MismatchInhibition   bool                // Determines if neurons firing outside a present main signal learn inhibition
+	UnlearnOnMismatch    bool                // Determines if neurons firing against negative training unlearn
+	CorrelationDecay     int                 // Determines how much unused synapses decay with each evocation
+	WeightCap            int                 // Determines the strongest graded weight, or bipolar weights when 0
+	LearningRule         LearningRule        // Determines how synapses are trained, CorrelativeHebbian when nil
+	Evaluation           Evaluation          // Determines how the neurons are evaluated during evocation
+	Workers              int                 // Determines the number of workers for the EvaluateWorkerPool strategy
 }
 
 // NewBasicGroup returns a new BasicGroup instance with an empty map of neuron instances
@@ -52,14 +85,18 @@ func (g *BasicGroup) GetId() string {
 	return g.id
 }
 
-// GetFirePattern returns the actively evoked firing pattern of this group. Once retrieved this value is reset.
+// GetFirePattern returns a copy of the actively evoked firing pattern of this group.
 func (g *BasicGroup) GetFirePattern() QualitativeSignal {
-	return g.pattern
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.pattern.Clone()
 }
 
 // GetMatchPattern returns a QualitativeSignal where each feature indicates the match condition of a neuron in the Group
 func (g *BasicGroup) GetMatchPattern() QualitativeSignal {
 	matchPattern := NewQualitativeSignal(g.id + "-match")
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	for addr, neuron := range g.neurons {
 		if neuron.match {
 			matchPattern.Features[addr] = 1
@@ -71,6 +108,8 @@ func (g *BasicGroup) GetMatchPattern() QualitativeSignal {
 // GetMisMatchPattern returns a QualitativeSignal where each feature indicates the mismatch condition of a neuron in the Group
 func (g *BasicGroup) GetMisMatchPattern() QualitativeSignal {
 	matchPattern := NewQualitativeSignal(g.id + "-mismatch")
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	for addr, neuron := range g.neurons {
 		if !neuron.match {
 			matchPattern.Features[addr] = 1
@@ -82,6 +121,8 @@ func (g *BasicGroup) GetMisMatchPattern() QualitativeSignal {
 // GetMatchLevel returns the number of matches which occurred during the latest call to Evoke this Group.
 // A negative match level indicates mismatches.
 func (g *BasicGroup) GetMatchLevel() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	matchLevel := 0
 	for _, neuron := range g.neurons {
 		if neuron.match {
@@ -95,6 +136,8 @@ func (g *BasicGroup) GetMatchLevel() int {
 
 func (g *BasicGroup) GetNoveltyPattern() QualitativeSignal {
 	noveltyPattern := NewQualitativeSignal(g.id + "-novelty")
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	for addr, neuron := range g.neurons {
 		if neuron.novelty {
 			noveltyPattern.Features[addr] = 1
@@ -104,6 +147,8 @@ func (g *BasicGroup) GetNoveltyPattern() QualitativeSignal {
 }
 
 func (g *BasicGroup) GetNoveltyLevel() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	noveltyLevel := 0
 	for _, neuron := range g.neurons {
 		if neuron.novelty {
@@ -119,36 +164,213 @@ func (g *BasicGroup) GetNoveltyLevel() int {
 // evocation neuron instances will be grown and trained. The evocation will cause
 // an update to the match signals which are retrievable with GetMatchPattern,
 // GetMisMatchPattern, and GetMatchLevel. The pattern is returned, but can also
-// be retrieved via GetPattern.
+// be retrieved via GetPattern. Neither the main nor the association signal is
+// modified, and the returned pattern is a copy which the caller is free to modify.
+// Concurrent calls to Evoke are serialized.
+//
+// A negative feature in the main signal is negative training: should the neuron
+// at its Address fire for the association, it learns to inhibit the association
+// instead. With MismatchInhibition set, every neuron absent from a main signal
+// with features receives negative training.
 func (g *BasicGroup) Evoke(main, association QualitativeSignal) QualitativeSignal {
+	return g.evokeAssociation(main, association, 1)
+}
+
+// EvokeGated behaves exactly as Evoke, but the neurons only learn when the gate signal holds a positive feature. With
+// a closed gate the BasicGroup recalls without growing neurons or changing any synapse.
+func (g *BasicGroup) EvokeGated(main, association, gate QualitativeSignal) QualitativeSignal {
+	return g.evokeAssociation(main, association, gateModulation(gate))
+}
+
+// EvokeModulated behaves exactly as Evoke, but the training of every neuron is multiplied by the level of the
+// modulation signal, the sum of its features. With pleasure, a positive level, the neurons of the main signal learn
+// in proportionally larger steps. With pain, a negative level, their training is reversed, so that a neuron which
+// fired for the association learns to inhibit it, or unlearns it with UnlearnOnMismatch set. With MismatchInhibition
+// set, only pleasure inhibits the neurons absent from the main signal. A level of 0 stops the BasicGroup from
+// learning, so that associations are only learnt when their outcome is known.
+func (g *BasicGroup) EvokeModulated(main, association, modulation QualitativeSignal) QualitativeSignal {
+	return g.evokeAssociation(main, association, modulationLevel(modulation))
+}
+
+// evokeAssociation evokes the BasicGroup with the association signal and the training modulated by the level.
+func (g *BasicGroup) evokeAssociation(main, association QualitativeSignal, modulation int) QualitativeSignal {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	l := g.learning()
+	l.modulation = modulation
+	l.frozen = l.frozen || modulation == 0
+	return g.evoke(main, l, func(n *neuron, training int, l learning) {
+		n.evoke(training, association, l)
+	})
+}
+
+// EvokeDense behaves exactly as Evoke, but takes the association signal as a DenseSignal. Each neuron evaluates the
+// DenseSignal directly, which is much faster for large association signals with a fixed geometry.
+func (g *BasicGroup) EvokeDense(main QualitativeSignal, association DenseSignal) QualitativeSignal {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.evoke(main, g.learning(), func(n *neuron, training int, l learning) {
+		n.evokeDense(training, &association, l)
+	})
+}
+
+// gateOpen reports whether the learning gate signal holds a positive feature.
+func gateOpen(gate QualitativeSignal) bool {
+	for _, feature := range gate.Features {
+		if feature > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// gateModulation returns the modulation level of the learning gate signal, 1 when it is open and 0 when it is closed.
+func gateModulation(gate QualitativeSignal) int {
+	if gateOpen(gate) {
+		return 1
+	}
+	return 0
+}
+
+// modulationLevel returns the level of the modulation signal, the sum of its features.
+func modulationLevel(modulation QualitativeSignal) int {
+	level := 0
+	for _, feature := range modulation.Features {
+		level += feature
+	}
+	return level
+}
+
+// evoke grows the neurons for the main signal, evaluates every neuron with evokeNeuron and collects the firing
+// pattern. Each neuron is evaluated with the learning settings, l, frozen for the neurons outside the learning mask,
+// and with its training multiplied by the modulation of l. Neurons are only grown where they would learn. The caller
+// must hold the lock of the BasicGroup.
+func (g *BasicGroup) evoke(main QualitativeSignal, l learning,
+	evokeNeuron func(n *neuron, training int, l learning)) QualitativeSignal {
 	if g.PassThrough {
-		g.pattern = main
+		g.pattern = main.Threshold(1) // Negative training never passes through, and the pattern must not alias main
 	} else {
 		g.pattern = NewQualitativeSignal(main.Id)
 	}
 	// Test the incoming signal for building new neurons
 	for addr := range main.Features {
-		if _, ok := g.neurons[addr]; !ok { // Does the BasicGroup have a neuron at the main feature address
-			g.neurons[addr] = newNeuron() // If not, create a new neuron
+		if _, ok := g.neurons[addr]; !ok && !l.frozen && g.masks(addr) { // Can the BasicGroup grow a neuron here
+			g.neurons[addr] = newNeuron() // If so, create a new neuron
 		}
 	}
 	// Test each neuron for firing strength.
-	var wg sync.WaitGroup
+	addresses := make([]Address, 0, len(g.neurons))
+	neurons := make([]*neuron, 0, len(g.neurons))
 	for addr, neuron := range g.neurons {
-		wg.Add(1)
-		go neuron.asyncEvoke(main.Features[addr], association, g.CorrelationThreshold, &wg)
+		addresses = append(addresses, addr)
+		neurons = append(neurons, neuron)
 	}
-	wg.Wait()
+	mismatch := g.MismatchInhibition && len(main.Features) > 0 && l.modulation > 0
+	evaluate(g.Evaluation, g.Workers, len(neurons), func(i int) {
+		training := main.Features[addresses[i]] * l.modulation
+		if training == 0 && mismatch {
+			training = -l.modulation
+		}
+		nl := l
+		nl.frozen = nl.frozen || !g.masks(addresses[i])
+		evokeNeuron(neurons[i], training, nl)
+	})
 	// Retrieve the firing strength of each neuron and adjust the firing Pattern accordingly
 	for address, neuron := range g.neurons {
 		if neuron.axon > 0 {
 			g.pattern.Features[address] += neuron.axon
 		}
 	}
-	if g.WTA >= 0 {
+	if g.Competition != nil {
+		g.pattern = g.Competition.Compete(g.pattern)
+	} else if g.WTA >= 0 {
 		g.pattern.WinnerTakesAll(g.WTA)
 	}
-	return g.pattern
+	return g.pattern.Clone()
+}
+
+// masks reports whether the neuron at the Address may learn under the learning mask of the BasicGroup.
+func (g *BasicGroup) masks(addr Address) bool {
+	return g.learningMask == nil || g.learningMask[addr]
+}
+
+// Freeze stops every neuron of the BasicGroup from learning, so that it can be evoked for recall without changing what
+// it has learnt. A frozen BasicGroup grows no neurons or synapses.
+func (g *BasicGroup) Freeze() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.frozen = true
+}
+
+// Unfreeze lets the neurons of the BasicGroup learn again after Freeze.
+func (g *BasicGroup) Unfreeze() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.frozen = false
+}
+
+// Frozen reports whether the BasicGroup is frozen.
+func (g *BasicGroup) Frozen() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.frozen
+}
+
+// SetLearningMask limits learning to the neurons at the Addresses of the positive features of the mask. Every other
+// neuron is evoked as usual but learns nothing, and no neuron is grown outside the mask. A mask without positive
+// features stops every neuron from learning.
+func (g *BasicGroup) SetLearningMask(mask QualitativeSignal) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.learningMask = make(map[Address]bool)
+	for addr, feature := range mask.Features {
+		if feature > 0 {
+			g.learningMask[addr] = true
+		}
+	}
+}
+
+// ClearLearningMask removes the learning mask, so that every neuron learns again.
+func (g *BasicGroup) ClearLearningMask() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.learningMask = nil
+}
+
+// learning returns the settings which govern how the neurons of the BasicGroup train their synapses.
+func (g *BasicGroup) learning() learning {
+	rule := g.LearningRule
+	if rule == nil {
+		rule = CorrelativeHebbian{}
+	}
+	return learning{
+		correlation: g.CorrelationThreshold,
+		decay:       g.CorrelationDecay,
+		unlearn:     g.UnlearnOnMismatch,
+		cap:         g.WeightCap,
+		rule:        rule,
+		frozen:      g.frozen,
+		modulation:  1,
+	}
+}
+
+// Forget removes the neuron at the Address, along with every association it has learnt. A new neuron is grown the
+// next time the Address is present in the main signal.
+func (g *BasicGroup) Forget(addr Address) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.neurons, addr)
+	delete(g.pattern.Features, addr)
+}
+
+// ForgetAssociation removes the synapses for the association feature at the Address from every neuron, so that no
+// neuron of the BasicGroup remembers anything it learnt about that feature.
+func (g *BasicGroup) ForgetAssociation(addr Address) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, n := range g.neurons {
+		n.forgetAssociation(addr)
+	}
 }
 
 // AsyncEvoke will Evoke this Group as a member of a WaitGroup
@@ -168,9 +390,10 @@ func (g *BasicGroup) AsyncEvoke(main, association QualitativeSignal, wg *sync.Wa
 // combination of association signals, and only one neuron will identify each of
 // those possible patterns for evocation in the second group.
 type AdvancedGroup struct {
-	grdNeurons              []*neuron // The neuron instances that compose this AdvancedGroup's N->1 map
-	GrdCorrelationThreshold int       // Determines the threshold for synaptic learning in the grandmother set
-	*BasicGroup                       // The component BasicGroup
+	grdMu                   sync.Mutex // Serializes evocation and guards the grandmother set
+	grdNeurons              []*neuron  // The neuron instances that compose this AdvancedGroup's N->1 map
+	GrdCorrelationThreshold int        // Determines the threshold for synaptic learning in the grandmother set
+	*BasicGroup                        // The component BasicGroup
 }
 
 // NewAdvancedGroup returns a new AdvancedGroup instance with an empty map of grandmother neurons
@@ -183,15 +406,57 @@ func NewAdvancedGroup(id string) *AdvancedGroup {
 // Evoke will test the AdvancedGroup for an associative evocation pattern. The
 // Advanced Group will grow an additional neuron for each additional possible
 // signal pattern. Therefore, a neuron must only be grown if there is no evocation by the existing set and
-// when the previously grown neuron is done learning.
+// when the previously grown neuron is done learning. Concurrent calls to Evoke are serialized.
 func (g *AdvancedGroup) Evoke(main QualitativeSignal, association QualitativeSignal) QualitativeSignal {
+	g.grdMu.Lock()
+	defer g.grdMu.Unlock()
+	return g.evoke(main, 1, func(n *neuron, l learning) {
+		n.evoke(1, association, l)
+	})
+}
+
+// EvokeGated behaves exactly as Evoke, but neither the grandmother set nor the component BasicGroup learns unless the
+// gate signal holds a positive feature.
+func (g *AdvancedGroup) EvokeGated(main, association, gate QualitativeSignal) QualitativeSignal {
+	g.grdMu.Lock()
+	defer g.grdMu.Unlock()
+	return g.evoke(main, gateModulation(gate), func(n *neuron, l learning) {
+		n.evoke(1, association, l)
+	})
+}
+
+// EvokeModulated behaves exactly as Evoke, but the training of the component BasicGroup is modulated as by
+// BasicGroup.EvokeModulated. The grandmother set learns the association whenever the level is not 0, whether the
+// outcome is pleasure or pain, as it only tells the association patterns apart.
+func (g *AdvancedGroup) EvokeModulated(main, association, modulation QualitativeSignal) QualitativeSignal {
+	g.grdMu.Lock()
+	defer g.grdMu.Unlock()
+	return g.evoke(main, modulationLevel(modulation), func(n *neuron, l learning) {
+		n.evoke(1, association, l)
+	})
+}
+
+// EvokeDense behaves exactly as Evoke, but takes the association signal as a DenseSignal, which is evaluated directly
+// by the grandmother set.
+func (g *AdvancedGroup) EvokeDense(main QualitativeSignal, association DenseSignal) QualitativeSignal {
+	g.grdMu.Lock()
+	defer g.grdMu.Unlock()
+	return g.evoke(main, 1, func(n *neuron, l learning) {
+		n.evokeDense(1, &association, l)
+	})
+}
+
+// evoke evaluates every grandmother neuron with evokeNeuron, grows the grandmother set if needed, and evokes the
+// component BasicGroup with the resulting grandmother signal and the training modulated by the level. Nothing is
+// learnt when the level is 0 or the AdvancedGroup is frozen. The caller must hold the grandmother lock.
+func (g *AdvancedGroup) evoke(main QualitativeSignal, modulation int,
+	evokeNeuron func(n *neuron, l learning)) QualitativeSignal {
 	grandmotherSignal := NewQualitativeSignal(g.id + "-grandmother")
-	var wg sync.WaitGroup
-	for _, neuron := range g.grdNeurons {
-		wg.Add(1)
-		go neuron.asyncEvoke(1, association, g.GrdCorrelationThreshold, &wg)
-	}
-	wg.Wait()
+	frozen := modulation == 0 || g.Frozen()
+	l := learning{correlation: g.GrdCorrelationThreshold, rule: CorrelativeHebbian{}, frozen: frozen}
+	evaluate(g.Evaluation, g.Workers, len(g.grdNeurons), func(i int) {
+		evokeNeuron(g.grdNeurons[i], l)
+	})
 	// Retrieve the firing strength of each neuron and adjust the firing Pattern accordingly
 	for i, neuron := range g.grdNeurons {
 		if neuron.axon > 0 {
@@ -201,15 +466,25 @@ func (g *AdvancedGroup) Evoke(main QualitativeSignal, association QualitativeSig
 	grandmotherSignal.WinnerTakesAll(0)
 	// Test for new neuron growth
 	topNeuron := g.grdNeurons[len(g.grdNeurons)-1]
-	if topNeuron.getSumOfWeights() > 0 {
+	if !frozen && topNeuron.getSumOfWeights() > 0 {
 		topNeuron.learningEnabled = false
 		if len(grandmotherSignal.Features) == 0 {
 			g.grdNeurons = append(g.grdNeurons, newNeuron())
 		}
 	}
 	// Send the main and grandmother signal through the basic neuron group
-	g.pattern = g.BasicGroup.Evoke(main, grandmotherSignal)
-	return g.pattern
+	return g.BasicGroup.evokeAssociation(main, grandmotherSignal, modulation)
+}
+
+// ForgetAssociation removes the synapses for the association feature at the Address from every grandmother neuron,
+// so that the AdvancedGroup no longer recognizes any pattern by that feature. The component BasicGroup associates the
+// main signal with the grandmother signal rather than the association signal, so it is left unchanged.
+func (g *AdvancedGroup) ForgetAssociation(addr Address) {
+	g.grdMu.Lock()
+	defer g.grdMu.Unlock()
+	for _, n := range g.grdNeurons {
+		n.forgetAssociation(addr)
+	}
 }
 
 // AsyncEvoke will Evoke this Group as a member of a WaitGroup
@@ -224,10 +499,12 @@ func (g *AdvancedGroup) AsyncEvoke(main QualitativeSignal, association Qualitati
 type neuron struct {
 	// Internal Attributes
 	synapses        map[Address]*Synapse
+	dense           *denseSynapses // The synapses laid out over the bounding box of the last DenseSignal evaluated
 	axon            int
 	match           bool
 	novelty         bool
 	learningEnabled bool
+	average         int // The running average of the training of the neuron, scaled by ActivityScale
 }
 
 func newNeuron() *neuron {
@@ -236,21 +513,29 @@ func newNeuron() *neuron {
 	return &n
 }
 
-// getSumOfWeights returns the amount of synapses which have learnt their weight values. In a bipolar system, this is
-// equal to the difference between the number of synapses and the true sum of weights.
+// forgetAssociation removes the synapse for the association feature at the Address, if the neuron has one.
+func (n *neuron) forgetAssociation(addr Address) {
+	if _, ok := n.synapses[addr]; ok {
+		delete(n.synapses, addr)
+		n.dense = nil // The dense view still holds the removed synapse
+	}
+}
+
+// getSumOfWeights returns the amount of synapses which have learnt to excite the neuron.
 func (n *neuron) getSumOfWeights() int {
-	weightSum := 0
-	count := len(n.synapses)
+	count := 0
 	for _, syn := range n.synapses {
-		weightSum += syn.weightValue
+		if syn.excitatory() {
+			count++
+		}
 	}
-	return count + weightSum // Fancy.
+	return count
 }
 
 // evoke tests the neuron for firing and writes the fired value to the 'axon'
 // channel. If the firing state does not evoke in the presence of the training
 // signal, the synaptic association trains itself.
-func (n *neuron) evoke(training int, associative QualitativeSignal, correlation int) {
+func (n *neuron) evoke(training int, associative QualitativeSignal, l learning) {
 	sum := 0
 	// Test the neuron synaptic associative evocations, if there is not a synapse present to handle the association
 	// feature then a new synapse will be made.
@@ -258,21 +543,22 @@ func (n *neuron) evoke(training int, associative QualitativeSignal, correlation
 		if syn, ok := n.synapses[featureAddress]; ok {
 			value := syn.Evoke(feature)
 			sum += value
-		} else {
+		} else if !l.frozen {
 			n.synapses[featureAddress] = NewSynapse()
+			n.dense = nil // The dense view no longer holds every synapse
 		}
 	}
 	// Training should occur on the condition of a novelty state being produced by
 	// the current system and only when learning has been enabled
-	if n.learningEnabled {
-		for featureAddress, feature := range associative.Features {
-			if syn, ok := n.synapses[featureAddress]; ok {
-				if (sum <= 0) && (training != 0) {
-					n.novelty = true
-					syn.Train(training, feature, correlation)
-				}
+	if n.learningEnabled && !l.frozen {
+		n.learn(sum, training, l, func(visit func(syn *Synapse, association int)) {
+			for featureAddress, feature := range associative.Features {
+				visit(n.synapses[featureAddress], feature)
 			}
-		}
+		}, func(addr Address) bool {
+			_, ok := associative.Features[addr]
+			return ok
+		})
 	}
 	// In the case that both signals are the same polarity, match is true.
 	// In the case that both signals are of different polarity, match is false.
@@ -280,25 +566,109 @@ func (n *neuron) evoke(training int, associative QualitativeSignal, correlation
 	n.axon = sum
 }
 
-// asyncEvoke will evoke this neuron as a member of a WaitGroup
-func (n *neuron) asyncEvoke(training int, associative QualitativeSignal, correlation int, wg *sync.WaitGroup) {
-	defer wg.Done()
-	n.evoke(training, associative, correlation)
+// learning holds the settings of a Group which govern how its neurons train their synapses.
+type learning struct {
+	correlation int          // The threshold for synaptic learning
+	decay       int          // The amount the correlation of an unused synapse decays by with each evocation
+	unlearn     bool         // Whether excitatory synapses unlearn on mismatch
+	cap         int          // The strongest graded weight, or 0 for bipolar weights
+	rule        LearningRule // The rule which trains the synapses
+	frozen      bool         // Whether the synapses are left unchanged, and no synapse is grown
+	modulation  int          // The factor the training of the neurons of a BasicGroup is multiplied by
 }
 
-// The Synapse performs the crucial job of connecting associations to neuron groups. Each synapse has a certain weight
-// which is either negative 1 or positive one. Once the weight has been set to one via +3:-1 Hebbian learning, the weight
-// must be reset by a raise in the correlation sum threshold value.
+// reinforces reports whether a neuron which fires for positive training keeps training its synapses.
+func (l learning) reinforces() bool {
+	return l.decay > 0 || l.cap > 0
+}
+
+// skips reports whether the active synapses of a neuron with the given sum and training are certainly left unchanged,
+// so that they need not be visited. Only the behaviour of the CorrelativeHebbian rule is known in advance.
+func (l learning) skips(sum, training int) bool {
+	if _, ok := l.rule.(CorrelativeHebbian); !ok {
+		return false
+	}
+	if sum > 0 {
+		return !((training < 0) || ((training > 0) && l.reinforces()))
+	}
+	return training <= 0
+}
+
+// learn trains the synapses of the neuron after an evocation with the given sum and training. forEachActive visits
+// every synapse whose association feature is active with the value of the feature, and active reports whether the
+// association feature at an Address is active. Inactive synapses are only visited by rules which train them and
+// when synapses decay.
+func (n *neuron) learn(sum, training int, l learning, forEachActive func(visit func(syn *Synapse, association int)),
+	active func(addr Address) bool) {
+	a := Activity{
+		Training:    training,
+		Sum:         sum,
+		Average:     n.average,
+		Correlation: l.correlation,
+		Cap:         l.cap,
+		Unlearn:     l.unlearn,
+		Reinforce:   l.reinforces(),
+	}
+	novel := ((sum <= 0) && (training > 0)) || ((sum > 0) && (training < 0))
+	if !l.skips(sum, training) {
+		forEachActive(func(syn *Synapse, association int) {
+			a.Association = association
+			l.rule.Learn(syn, a)
+			n.novelty = n.novelty || novel
+		})
+	}
+	if inactive := l.rule.TrainsInactive(); inactive || l.decay > 0 {
+		a.Association = 0
+		for addr, syn := range n.synapses {
+			if active(addr) {
+				continue
+			}
+			if inactive {
+				l.rule.Learn(syn, a)
+			}
+			if l.decay > 0 {
+				// Decay weakens the synapses which are not in use, so that unused associations are forgotten
+				syn.Decay(l.decay, l.correlation)
+				if l.cap > 0 {
+					syn.grade(l.correlation, l.cap)
+				}
+			}
+		}
+	}
+	n.average = runningAverage(n.average, training)
+}
+
+// The three states of a Synapse weight. A neutral Synapse has not learnt anything and weakly opposes firing, an
+// excitatory Synapse has learnt to evoke firing, and an inhibitory Synapse has learnt to suppress firing strongly
+// enough to outweigh several excitatory Synapses. A graded excitatory Synapse may have a weight above
+// ExcitatoryWeight.
+const (
+	InhibitoryWeight = -4
+	NeutralWeight    = -1
+	ExcitatoryWeight = 1
+)
+
+// The Synapse performs the crucial job of connecting associations to neuron groups. Each synapse has a weight in one
+// of three states: neutral, excitatory or inhibitory. The weight is set to excitatory via +3:-1 Hebbian learning. A
+// neutral synapse becomes inhibitory via the same +3:-1 learning on mismatch, when its neuron fires against negative
+// training, which lets a neuron learn exclusions such as "not red". A learnt weight returns to neutral when it is
+// unlearnt on mismatch, when its sums decay, or when the synapse is forgotten.
+//
+// In a graded group the weight of an excitatory synapse grows with its correlation sum, by one for every +3 step
+// above the correlation threshold, up to the cap of the group. Recall strength then reflects how often an association
+// has been experienced rather than only whether it has been learnt.
 type Synapse struct {
 	// Internal Attributes
 	correlationSum int
+	inhibitionSum  int
 	weightValue    int
+	average        int // The running average of the association, scaled by ActivityScale
 }
 
-// NewSynapse initializes a new Synapse with a -1 weight value and a 0 correlation sum. A pointer to the Synapse is
-// returned.
+// NewSynapse initializes a new Synapse with a neutral weight value and a 0 correlation sum. A pointer to the Synapse
+// is returned.
 func NewSynapse() *Synapse {
-	syn := &Synapse{weightValue: -1, correlationSum: 0}
+	syn := &Synapse{weightValue: NeutralWeight, correlationSum: 0}
 	return syn
 }
 
@@ -308,15 +678,151 @@ func (syn *Synapse) Evoke(association int) int {
 	return association * syn.weightValue
 }
 
+// Weight returns the weight value of the synapse.
+func (syn *Synapse) Weight() int {
+	return syn.weightValue
+}
+
+// CorrelationSum returns the correlation sum the synapse has learnt.
+func (syn *Synapse) CorrelationSum() int {
+	return syn.correlationSum
+}
+
+// InhibitionSum returns the inhibition sum the synapse has learnt.
+func (syn *Synapse) InhibitionSum() int {
+	return syn.inhibitionSum
+}
+
+// Average returns the running average of the association of the synapse, scaled by ActivityScale. The average is
+// only kept by learning rules which Observe the association.
+func (syn *Synapse) Average() int {
+	return syn.average
+}
+
+// Observe updates the running average of the association of the synapse.
+func (syn *Synapse) Observe(association int) {
+	syn.average = runningAverage(syn.average, association)
+}
+
+// Adjust changes the correlation sum of the synapse by the delta, which may be negative, and updates the weight at
+// once. A synapse whose correlation sum is above the correlation threshold is excitatory, graded up to the cap when
+// the cap is above 0, while an excitatory synapse whose sum falls to the threshold or below returns to neutral.
+// Inhibitory synapses keep their weight.
+func (syn *Synapse) Adjust(delta int, correlation int, cap int) {
+	syn.correlationSum += delta
+	if syn.weightValue == InhibitoryWeight {
+		return
+	}
+	if syn.correlationSum > correlation {
+		syn.weightValue = ExcitatoryWeight
+		if cap > 0 {
+			syn.grade(correlation, cap)
+		}
+	} else {
+		syn.weightValue = NeutralWeight
+	}
+}
+
 // Train trains the synapse for later evocation. For learning we use an optimized
 // correlative Hebbian learning algorithm for training, which prioritizes
-// bit-shifting for multiplications and performs +3:-1 incremental steps.
+// bit-shifting for multiplications and performs +3:-1 incremental steps. An
+// inhibitory synapse is left unchanged, so a learnt exclusion is kept until it
+// decays or is forgotten.
 func (syn *Synapse) Train(training int, association int, correlation int) {
+	if syn.weightValue == InhibitoryWeight {
+		return
+	}
 	if syn.correlationSum > correlation {
-		syn.weightValue = 1
+		syn.weightValue = ExcitatoryWeight
+	} else {
+		syn.weightValue = NeutralWeight
+		syn.correlationSum += 4 * association * training
+		syn.correlationSum -= association
+	}
+}
+
+// Inhibit trains the synapse to suppress its neuron, given the negative training of a mismatch. The inhibition sum
+// follows the same +3:-1 steps as Train, and once it passes the correlation threshold the weight becomes inhibitory.
+// Excitatory synapses are left unchanged, so a neuron keeps the associations it has learnt and only learns to inhibit
+// the features that set the mismatching context apart.
+func (syn *Synapse) Inhibit(training int, association int, correlation int) {
+	if syn.excitatory() {
+		return
+	}
+	if syn.inhibitionSum > correlation {
+		syn.weightValue = InhibitoryWeight
 	} else {
-		syn.weightValue = -1
+		syn.inhibitionSum -= 4 * association * training
+		syn.inhibitionSum -= association
+	}
+}
+
+// excitatory reports whether the synapse has learnt to evoke firing.
+func (syn *Synapse) excitatory() bool {
+	return syn.weightValue > 0
+}
+
+// grade sets the weight of an excitatory synapse from its correlation sum, between ExcitatoryWeight and the cap.
+func (syn *Synapse) grade(correlation int, cap int) {
+	if !syn.excitatory() {
+		return
+	}
+	level := (syn.correlationSum - correlation + 2) / 3
+	if level < ExcitatoryWeight {
+		level = ExcitatoryWeight
+	}
+	if level > cap {
+		level = cap
+	}
+	syn.weightValue = level
+}
+
+// reinforce raises the correlation sum of an excitatory synapse in the same steps as Train.
+func (syn *Synapse) reinforce(training int, association int) {
+	if syn.excitatory() {
 		syn.correlationSum += 4 * association * training
 		syn.correlationSum -= association
 	}
 }
+
+// Unlearn trains an excitatory synapse away from its association, given the negative training of a mismatch. The
+// correlation sum falls in the same steps as it rose in Train, and once it is no longer above the correlation
+// threshold the weight returns to neutral. Other synapses are left unchanged.
+func (syn *Synapse) Unlearn(training int, association int, correlation int) {
+	if !syn.excitatory() {
+		return
+	}
+	if syn.correlationSum <= correlation {
+		syn.weightValue = NeutralWeight
+	} else {
+		syn.correlationSum += 4 * association * training
+		syn.correlationSum += association
+	}
+}
+
+// Decay moves the correlation and inhibition sums of the synapse towards 0 by the amount. A learnt weight whose sum
+// falls to the correlation threshold or below is forgotten and returns to neutral.
+func (syn *Synapse) Decay(amount int, correlation int) {
+	syn.correlationSum = towardsZero(syn.correlationSum, amount)
+	syn.inhibitionSum = towardsZero(syn.inhibitionSum, amount)
+	if (syn.excitatory() && syn.correlationSum <= correlation) ||
+		(syn.weightValue == InhibitoryWeight && syn.inhibitionSum <= correlation) {
+		syn.weightValue = NeutralWeight
+	}
+}
+
+// Forget returns the synapse to the state of a new Synapse.
+func (syn *Synapse) Forget() {
+	*syn = Synapse{weightValue: NeutralWeight}
+}
+
+// towardsZero moves the value towards 0 by the amount, without passing 0.
+func towardsZero(value, amount int) int {
+	if value > amount {
+		return value - amount
+	}
+	if value < -amount {
+		return value + amount
+	}
+	return 0
+}
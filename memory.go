@@ -0,0 +1,127 @@
+package gracious
+
+import (
+	"sync"
+)
+
+// A ShortTermMemory is a working memory which sustains recent percepts after the sensor that produced them has gone
+// silent. Each percept is held as a trace with a strength which weakens by Decay with every evocation, and the percept
+// is forgotten once its strength is spent. At most Capacity percepts are held at once; when a new percept arrives at a
+// full memory, the weakest percept is forgotten to make room. Attending to a held percept refreshes its strength, so
+// that a percept which remains the focus of attention is sustained indefinitely.
+//
+// The content of the ShortTermMemory is a single QualitativeSignal in which every feature of every held percept is
+// active with the strength of its trace. The content can be used directly as the association input of a Group.
+type ShortTermMemory struct {
+	mu          sync.Mutex    // Guards the traces
+	id          string        // The name of this ShortTermMemory
+	traces      []memoryTrace // The held percepts, from the oldest to the most recent
+	Capacity    int           // Determines the maximum number of percepts held at once
+	Persistence int           // Determines the strength of a percept as it is stored or refreshed
+	Decay       int           // Determines how much each held percept weakens with each evocation
+}
+
+// memoryTrace is a single percept held by a ShortTermMemory.
+type memoryTrace struct {
+	percept  QualitativeSignal
+	strength int
+}
+
+// NewShortTermMemory returns a new, empty ShortTermMemory which holds up to capacity percepts, each for persistence
+// evocations. The Decay of the ShortTermMemory is 1.
+func NewShortTermMemory(id string, capacity, persistence int) *ShortTermMemory {
+	m := ShortTermMemory{id: id, Capacity: capacity, Persistence: persistence, Decay: 1}
+	return &m
+}
+
+// GetId returns the id of this ShortTermMemory
+func (m *ShortTermMemory) GetId() string {
+	return m.id
+}
+
+// Evoke advances the ShortTermMemory by one moment of time. Every held percept decays, and then the percept, if it
+// has any features, is stored at full strength. A percept with exactly the features of a held percept refreshes that
+// percept rather than being stored twice. The content of the memory after the evocation is returned.
+func (m *ShortTermMemory) Evoke(percept QualitativeSignal) QualitativeSignal {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	held := m.traces[:0]
+	for _, trace := range m.traces {
+		if trace.strength -= m.Decay; trace.strength > 0 {
+			held = append(held, trace)
+		}
+	}
+	m.traces = held
+	if len(percept.Features) > 0 {
+		m.store(percept)
+	}
+	return m.content()
+}
+
+// Attend refreshes every held percept which shares at least one feature with the focus of attention to full strength.
+func (m *ShortTermMemory) Attend(focus QualitativeSignal) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, trace := range m.traces {
+		if trace.percept.Overlap(focus) > 0 {
+			m.traces[i].strength = m.Persistence
+		}
+	}
+}
+
+// GetContent returns the content of the ShortTermMemory without advancing it.
+func (m *ShortTermMemory) GetContent() QualitativeSignal {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.content()
+}
+
+// Len returns the number of percepts currently held.
+func (m *ShortTermMemory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.traces)
+}
+
+// Clear forgets every held percept.
+func (m *ShortTermMemory) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.traces = nil
+}
+
+// store holds the percept at full strength, refreshing an identical percept if one is held, and forgetting the
+// weakest percept if the memory is full. The caller must hold the lock.
+func (m *ShortTermMemory) store(percept QualitativeSignal) {
+	for i, trace := range m.traces {
+		if trace.percept.HammingDistance(percept) == 0 {
+			m.traces = append(m.traces[:i], m.traces[i+1:]...)
+			m.traces = append(m.traces, memoryTrace{percept: trace.percept, strength: m.Persistence})
+			return
+		}
+	}
+	if m.Capacity > 0 && len(m.traces) >= m.Capacity {
+		weakest := 0
+		for i, trace := range m.traces {
+			if trace.strength < m.traces[weakest].strength {
+				weakest = i
+			}
+		}
+		m.traces = append(m.traces[:weakest], m.traces[weakest+1:]...)
+	}
+	m.traces = append(m.traces, memoryTrace{percept: percept.Clone(), strength: m.Persistence})
+}
+
+// content composites every held percept, with each feature set to the strength of the strongest trace holding it.
+// The caller must hold the lock.
+func (m *ShortTermMemory) content() QualitativeSignal {
+	content := NewQualitativeSignal(m.id + "-content")
+	for _, trace := range m.traces {
+		for addr := range trace.percept.Features {
+			if content.Features[addr] < trace.strength {
+				content.Features[addr] = trace.strength
+			}
+		}
+	}
+	return content
+}
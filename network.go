@@ -0,0 +1,332 @@
+package gracious
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// A Source is any component which produces a QualitativeSignal without any inputs, such as the io.Sensor.
+type Source interface {
+	GetId() string
+	Evoke() QualitativeSignal
+}
+
+// An Output is any component which consumes a QualitativeSignal without producing one, such as the io.Actuator.
+type Output interface {
+	GetId() string
+	Actuate(signal QualitativeSignal)
+}
+
+// A Port identifies which input of a component an edge of a Network feeds.
+type Port int
+
+const (
+	MainPort        Port = iota // The main signal input of a component
+	AssociationPort             // The association signal input of a component
+	GatePort                    // The learning gate input of a GatedGroup
+	ModulationPort              // The pleasure and pain input of a ModulatedGroup
+)
+
+var (
+	// ErrDuplicateNode is returned when a component is added to a Network with the id of an existing node.
+	ErrDuplicateNode = errors.New("gracious: network already has a node with this id")
+	// ErrUnknownNode is returned when an edge refers to a node which is not part of the Network.
+	ErrUnknownNode = errors.New("gracious: network has no node with this id")
+	// ErrCycle is returned when an edge would close a loop of edges. Loops must be closed with a feedback edge.
+	ErrCycle = errors.New("gracious: edge would create a cycle, use a feedback edge")
+	// ErrNoGatePort is returned when an edge would feed the GatePort of a node which is not a GatedGroup.
+	ErrNoGatePort = errors.New("gracious: node has no learning gate")
+	// ErrNoModulationPort is returned when an edge would feed the ModulationPort of a node which is not a
+	// ModulatedGroup.
+	ErrNoModulationPort = errors.New("gracious: node has no modulation input")
+)
+
+// A Network wires Sources, Groups and Outputs together into a single system. Each component is a node of the Network,
+// identified by its id, and the nodes are joined by edges which feed the output of one node into the main or
+// association input of another. Where several edges feed the same input, their signals are composited. Edges into
+// the GatePort of a GatedGroup gate its learning: a GatedGroup with gate edges only learns while they carry a
+// positive feature. Edges into the ModulationPort of a ModulatedGroup modulate its learning with pleasure and pain,
+// such as the signal of a sensor which reports the outcome of an action.
+//
+// Each call to Step evaluates every node once, in dependency order, so that a node is only evaluated once every node
+// feeding it has been evaluated. Loops are closed with feedback edges, which carry the output a node produced during
+// the previous Step.
+//
+// A Network is also Clocked, so it can be driven by a Clock instead of Step. Under a Clock every edge carries the
+// output its node published during the previous tick, and the nodes are evaluated in parallel.
+type Network struct {
+	mu      sync.Mutex
+	id      string
+	nodes   map[string]*node
+	ordered []*node // Every node, in the order they were added
+	order   []*node // Every node, in dependency order. Nil whenever the edges have changed.
+}
+
+// node is a single component of a Network.
+type node struct {
+	id      string
+	evoke   func(main, association QualitativeSignal) QualitativeSignal
+	group   Group // The Group evoked by the node, or nil if the node is not a Group
+	inputs  []edge
+	output  QualitativeSignal // The output of the node during the latest Step or tick
+	pending QualitativeSignal // The output of the node computed during the sample phase of a tick
+}
+
+// edge feeds the output of the node, from, into an input of the node it belongs to.
+type edge struct {
+	from     *node
+	port     Port
+	feedback bool
+}
+
+// NewNetwork returns a new Network instance with no nodes.
+func NewNetwork(id string) *Network {
+	n := Network{id: id, nodes: make(map[string]*node)}
+	return &n
+}
+
+// GetId returns the id of this Network
+func (n *Network) GetId() string {
+	return n.id
+}
+
+// AddComponent adds a node to the Network which is evaluated by calling evoke with its main and association inputs.
+// The value returned by evoke is the output of the node. AddComponent allows any component, such as a
+// ShortTermMemory, to take part in a Network.
+func (n *Network) AddComponent(id string, evoke func(main, association QualitativeSignal) QualitativeSignal) error {
+	return n.addComponent(node{id: id, evoke: evoke})
+}
+
+func (n *Network) addComponent(nd node) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	id := nd.id
+	if _, ok := n.nodes[id]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateNode, id)
+	}
+	nd.output = NewQualitativeSignal(id)
+	n.nodes[id] = &nd
+	n.ordered = append(n.ordered, &nd)
+	n.order = nil
+	return nil
+}
+
+// AddSource adds a Source, such as an io.Sensor, to the Network. A Source has no inputs.
+func (n *Network) AddSource(s Source) error {
+	return n.AddComponent(s.GetId(), func(QualitativeSignal, QualitativeSignal) QualitativeSignal {
+		return s.Evoke()
+	})
+}
+
+// AddGroup adds a Group to the Network. The Group is evoked with its main and association inputs. A GatedGroup also
+// has a GatePort, and a ModulatedGroup a ModulationPort.
+func (n *Network) AddGroup(g Group) error {
+	return n.addComponent(node{id: g.GetId(), evoke: g.Evoke, group: g})
+}
+
+// AddOutput adds an Output to the Network. The Output is actuated with its main input, which is also the output of
+// the node.
+func (n *Network) AddOutput(o Output) error {
+	return n.AddComponent(o.GetId(), func(main, _ QualitativeSignal) QualitativeSignal {
+		o.Actuate(main)
+		return main
+	})
+}
+
+// Connect adds an edge feeding the output of the node, from, into the port of the node, to, during the same Step.
+// ErrCycle is returned if the edge would close a loop; such an edge must be added with Feedback instead.
+func (n *Network) Connect(from, to string, port Port) error {
+	return n.connect(from, to, port, false)
+}
+
+// Feedback adds an edge feeding the output the node, from, produced during the previous Step into the port of the
+// node, to. Feedback edges may close loops, including a loop from a node into itself.
+func (n *Network) Feedback(from, to string, port Port) error {
+	return n.connect(from, to, port, true)
+}
+
+func (n *Network) connect(from, to string, port Port, feedback bool) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	source, ok := n.nodes[from]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownNode, from)
+	}
+	target, ok := n.nodes[to]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownNode, to)
+	}
+	if _, ok := target.group.(GatedGroup); port == GatePort && !ok {
+		return fmt.Errorf("%w: %q", ErrNoGatePort, to)
+	}
+	if _, ok := target.group.(ModulatedGroup); port == ModulationPort && !ok {
+		return fmt.Errorf("%w: %q", ErrNoModulationPort, to)
+	}
+	if !feedback && (source == target || n.reaches(target, source)) {
+		return fmt.Errorf("%w: %q to %q", ErrCycle, from, to)
+	}
+	target.inputs = append(target.inputs, edge{from: source, port: port, feedback: feedback})
+	n.order = nil
+	return nil
+}
+
+// reaches reports whether there is a path of non-feedback edges from the node, from, to the node, to.
+func (n *Network) reaches(from, to *node) bool {
+	visited := make(map[*node]bool)
+	stack := []*node{to}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if current == from {
+			return true
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		for _, e := range current.inputs {
+			if !e.feedback {
+				stack = append(stack, e.from)
+			}
+		}
+	}
+	return false
+}
+
+// Step evaluates every node of the Network once, in dependency order. The outputs of the nodes are retrievable with
+// GetOutput until the next Step.
+func (n *Network) Step() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	previous := make(map[*node]QualitativeSignal, len(n.ordered))
+	for _, nd := range n.ordered {
+		previous[nd] = nd.output
+	}
+	for _, nd := range n.dependencyOrder() {
+		nd.output = nd.evaluate(func(e edge) QualitativeSignal {
+			if e.feedback {
+				return previous[e.from]
+			}
+			return e.from.output
+		})
+	}
+}
+
+// Sample evaluates every node of the Network in parallel from the outputs published during the previous tick.
+func (n *Network) Sample(tick uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var wg sync.WaitGroup
+	for _, nd := range n.ordered {
+		wg.Add(1)
+		go func(nd *node) {
+			defer wg.Done()
+			nd.pending = nd.evaluate(func(e edge) QualitativeSignal {
+				return e.from.output
+			})
+		}(nd)
+	}
+	wg.Wait()
+}
+
+// Publish publishes the outputs of every node computed during Sample.
+func (n *Network) Publish(tick uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, nd := range n.ordered {
+		nd.output = nd.pending
+	}
+}
+
+// GetOutput returns the output of the node with the id during the latest Step or tick. The second return value is
+// false if the Network has no such node.
+func (n *Network) GetOutput(id string) (QualitativeSignal, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	nd, ok := n.nodes[id]
+	if !ok {
+		return QualitativeSignal{}, false
+	}
+	return nd.output.Clone(), true
+}
+
+// evaluate composites the signals carried by the edges of the node into its inputs, as given by signal, and returns
+// the output of the node.
+func (nd *node) evaluate(signal func(e edge) QualitativeSignal) QualitativeSignal {
+	main := NewQualitativeSignal(nd.id + "-main")
+	association := NewQualitativeSignal(nd.id + "-association")
+	var gate, modulation *QualitativeSignal
+	for _, e := range nd.inputs {
+		switch e.port {
+		case MainPort:
+			main.Composite(signal(e))
+		case GatePort:
+			gate = compositeInto(gate, nd.id+"-gate", signal(e))
+		case ModulationPort:
+			modulation = compositeInto(modulation, nd.id+"-modulation", signal(e))
+		default:
+			association.Composite(signal(e))
+		}
+	}
+	if nd.group != nil {
+		return evokeGroup(nd.group, main, association, gate, modulation)
+	}
+	return nd.evoke(main, association)
+}
+
+// compositeInto composites the signal into the signal at target, creating the signal with the id when target is nil.
+func compositeInto(target *QualitativeSignal, id string, signal QualitativeSignal) *QualitativeSignal {
+	if target == nil {
+		s := NewQualitativeSignal(id)
+		target = &s
+	}
+	target.Composite(signal)
+	return target
+}
+
+// evokeGroup evokes the Group with the signals of its inputs. The gate and modulation are nil when their port has no
+// inputs. The modulation is only honored by a ModulatedGroup and the gate by a GatedGroup. A closed gate stops a
+// modulated Group from learning whatever its modulation.
+func evokeGroup(g Group, main, association QualitativeSignal, gate, modulation *QualitativeSignal) QualitativeSignal {
+	if modulated, ok := g.(ModulatedGroup); ok && modulation != nil {
+		if gate != nil && !gateOpen(*gate) {
+			return modulated.EvokeModulated(main, association, NewQualitativeSignal(modulation.Id))
+		}
+		return modulated.EvokeModulated(main, association, *modulation)
+	}
+	if gated, ok := g.(GatedGroup); ok && gate != nil {
+		return gated.EvokeGated(main, association, *gate)
+	}
+	return g.Evoke(main, association)
+}
+
+// dependencyOrder returns every node such that each node follows the nodes feeding it through non-feedback edges.
+// Nodes which do not depend on each other keep the order they were added in. The caller must hold the lock.
+func (n *Network) dependencyOrder() []*node {
+	if n.order != nil {
+		return n.order
+	}
+	placed := make(map[*node]bool, len(n.ordered))
+	order := make([]*node, 0, len(n.ordered))
+	for len(order) < len(n.ordered) {
+		for _, nd := range n.ordered {
+			if placed[nd] {
+				continue
+			}
+			ready := true
+			for _, e := range nd.inputs {
+				if !e.feedback && !placed[e.from] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				placed[nd] = true
+				order = append(order, nd)
+			}
+		}
+	}
+	n.order = order
+	return order
+}
@@ -0,0 +1,741 @@
+package gracious
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sort"
+)
+
+// featureSnapshot is the persisted form of a single feature of a QualitativeSignal.
+type featureSnapshot struct {
+	Address Address `json:"address"`
+	Value   int     `json:"value"`
+}
+
+// signalSnapshot is the persisted form of a QualitativeSignal. Features are stored as a sorted slice since Address
+// values can not be used as Json object keys.
+type signalSnapshot struct {
+	Id       string            `json:"id"`
+	Novelty  int               `json:"novelty"`
+	MisMatch int               `json:"misMatch"`
+	Features []featureSnapshot `json:"features"`
+}
+
+// synapseSnapshot is the persisted form of a Synapse and the Address of the association feature it is attached to.
+type synapseSnapshot struct {
+	Address        Address `json:"address"`
+	CorrelationSum int     `json:"correlationSum"`
+	InhibitionSum  int     `json:"inhibitionSum,omitempty"`
+	WeightValue    int     `json:"weightValue"`
+	Average        int     `json:"average,omitempty"`
+}
+
+// neuronSnapshot is the persisted form of a neuron. The Address is the neuron's position in a BasicGroup and is left
+// at the zero value for the grandmother neurons of an AdvancedGroup, whose position is their index.
+type neuronSnapshot struct {
+	Address         Address           `json:"address"`
+	Synapses        []synapseSnapshot `json:"synapses"`
+	Axon            int               `json:"axon"`
+	Match           bool              `json:"match"`
+	Novelty         bool              `json:"novelty"`
+	LearningEnabled bool              `json:"learningEnabled"`
+	Average         int               `json:"average,omitempty"`
+}
+
+// competitionSnapshot is the persisted form of one of the Competition strategies provided by Gracious. Every
+// strategy is described by its kind and a single parameter, except LateralInhibition which also has a Radius and an
+// optional Kernel, kept as a sorted slice of offsets and weights. The Kernel is a pointer so that an empty Kernel,
+// which inhibits nothing, is told apart from no Kernel, which falls back to the Radius and Strength.
+type competitionSnapshot struct {
+	Kind      string             `json:"kind"`
+	Parameter int                `json:"parameter"`
+	Radius    int                `json:"radius,omitempty"`
+	Kernel    *[]featureSnapshot `json:"kernel,omitempty"`
+}
+
+// learningRuleSnapshot is the persisted form of one of the LearningRule variants provided by Gracious, described by
+// its kind and a single parameter.
+type learningRuleSnapshot struct {
+	Kind      string `json:"kind"`
+	Parameter int    `json:"parameter,omitempty"`
+}
+
+// basicGroupSnapshot is the persisted form of a BasicGroup.
+type basicGroupSnapshot struct {
+	Id                   string                `json:"id"`
+	PassThrough          bool                  `json:"passThrough"`
+	WTA                  int                   `json:"wta"`
+	Competition          *competitionSnapshot  `json:"competition,omitempty"`
+	CorrelationThreshold int                   `json:"correlationThreshold"`
+	MismatchInhibition   bool                  `json:"mismatchInhibition,omitempty"`
+	UnlearnOnMismatch    bool                  `json:"unlearnOnMismatch,omitempty"`
+	CorrelationDecay     int                   `json:"correlationDecay,omitempty"`
+	WeightCap            int                   `json:"weightCap,omitempty"`
+	LearningRule         *learningRuleSnapshot `json:"learningRule,omitempty"`
+	Frozen               bool                  `json:"frozen,omitempty"`
+	LearningMask         *signalSnapshot       `json:"learningMask,omitempty"`
+	Evaluation           Evaluation            `json:"evaluation,omitempty"`
+	Workers              int                   `json:"workers,omitempty"`
+	Pattern              signalSnapshot        `json:"pattern"`
+	Neurons              []neuronSnapshot      `json:"neurons"`
+}
+
+// advancedGroupSnapshot is the persisted form of an AdvancedGroup. The grandmother neurons are kept in their original
+// order, as their index is the Address of the grandmother signal they produce.
+type advancedGroupSnapshot struct {
+	basicGroupSnapshot
+	GrdCorrelationThreshold int              `json:"grdCorrelationThreshold"`
+	GrdNeurons              []neuronSnapshot `json:"grdNeurons"`
+}
+
+// sequenceGroupSnapshot is the persisted form of a SequenceGroup.
+type sequenceGroupSnapshot struct {
+	basicGroupSnapshot
+	Trace         signalSnapshot `json:"trace"`
+	TraceStrength int            `json:"traceStrength"`
+	TraceDecay    int            `json:"traceDecay"`
+	TraceOffset   Address        `json:"traceOffset"`
+}
+
+// SchemaVersion is the version of the snapshot format written by Save. It must be raised, and a migration from the
+// previous version added to migrations, whenever a change to the internals of the neuron, Synapse or a Group alters
+// the meaning of a snapshot.
+const SchemaVersion = 8
+
+// Group type tags identify the kind of Group held in a snapshot.
+const (
+	BasicGroupType    = "BasicGroup"
+	AdvancedGroupType = "AdvancedGroup"
+	SequenceGroupType = "SequenceGroup"
+	ValenceGroupType  = "ValenceGroup"
+)
+
+const (
+	snapshotFormat = "gracious"                              // Identifies a versioned snapshot container
+	modulePath     = "github.com/Art-of-the-Living/gracious" // Used to find the module version in the build info
+)
+
+var (
+	// ErrNoGrandmotherNeurons is returned when loading an AdvancedGroup whose grandmother set is empty. An
+	// AdvancedGroup always holds at least one grandmother neuron.
+	ErrNoGrandmotherNeurons = errors.New("gracious: advanced group snapshot has no grandmother neurons")
+	// ErrFutureSchemaVersion is returned when a snapshot was written by a newer version of Gracious.
+	ErrFutureSchemaVersion = errors.New("gracious: snapshot schema version is newer than this version of gracious")
+	// ErrUnknownSchemaVersion is returned when there is no migration path for a snapshot's version.
+	ErrUnknownSchemaVersion = errors.New("gracious: no migration registered for snapshot schema version")
+	// ErrUnexpectedGroupType is returned when a snapshot holds a different type of Group than was requested.
+	ErrUnexpectedGroupType = errors.New("gracious: snapshot holds an unexpected group type")
+	// ErrUnsupportedCompetition is returned when saving a group whose Competition is not one of the strategies
+	// provided by Gracious, or when loading a snapshot with an unknown Competition kind.
+	ErrUnsupportedCompetition = errors.New("gracious: competition can not be persisted")
+	// ErrUnsupportedLearningRule is returned when saving a group whose LearningRule is not one of the rules provided
+	// by Gracious, or when loading a snapshot with an unknown LearningRule kind.
+	ErrUnsupportedLearningRule = errors.New("gracious: learning rule can not be persisted")
+)
+
+// A migration upgrades the Json payload of a snapshot of the given group type by exactly one schema version.
+type migration func(groupType string, payload json.RawMessage) (json.RawMessage, error)
+
+// migrations holds the migration from every schema version before SchemaVersion to the next version. The chain is
+// fixed when Gracious is built, so that snapshots of every earlier version are always loaded the same way.
+var migrations = map[int]migration{
+	// Version 0 snapshots are the bare group Json written before the versioned container existed. The group
+	// payload itself is unchanged by version 1.
+	0: func(groupType string, payload json.RawMessage) (json.RawMessage, error) {
+		return payload, nil
+	},
+	// Version 2 adds the optional Competition of a group. A snapshot without one still evokes with its WTA, so
+	// the group payload is unchanged. The version is raised so that older versions of Gracious refuse snapshots
+	// whose Competition they would silently ignore.
+	1: func(groupType string, payload json.RawMessage) (json.RawMessage, error) {
+		return payload, nil
+	},
+	// Version 3 adds the layer, Z, to every Address. Addresses without one lie on layer 0, so the group payload
+	// is unchanged, but older versions of Gracious would merge the layers of a snapshot.
+	2: func(groupType string, payload json.RawMessage) (json.RawMessage, error) {
+		return payload, nil
+	},
+	// Version 4 adds inhibitory synapses. Snapshots of earlier versions hold only neutral and excitatory weights
+	// and no inhibition sums, so the group payload is unchanged.
+	3: func(groupType string, payload json.RawMessage) (json.RawMessage, error) {
+		return payload, nil
+	},
+	// Version 5 adds forgetting to a group. Snapshots of earlier versions never forget, which is the default.
+	4: func(groupType string, payload json.RawMessage) (json.RawMessage, error) {
+		return payload, nil
+	},
+	// Version 6 adds graded weights. Snapshots of earlier versions hold bipolar weights only, which is the default.
+	5: func(groupType string, payload json.RawMessage) (json.RawMessage, error) {
+		return payload, nil
+	},
+	// Version 7 adds the LearningRule of a group and the running averages of activity kept by some rules.
+	// Snapshots of earlier versions train with the CorrelativeHebbian rule, which is the default.
+	6: func(groupType string, payload json.RawMessage) (json.RawMessage, error) {
+		return payload, nil
+	},
+	// Version 8 adds freezing and the learning mask of a group. Snapshots of earlier versions are never frozen and
+	// every neuron learns, which is the default.
+	7: func(groupType string, payload json.RawMessage) (json.RawMessage, error) {
+		return payload, nil
+	},
+}
+
+// container is the versioned envelope that every snapshot is written in.
+type container struct {
+	Format        string          `json:"format"`
+	SchemaVersion int             `json:"schemaVersion"`
+	ModuleVersion string          `json:"moduleVersion"`
+	Type          string          `json:"type"`
+	Group         json.RawMessage `json:"group"`
+}
+
+// Save writes the complete state of the BasicGroup to w as a versioned Json snapshot. This includes every neuron and
+// Synapse, so a trained BasicGroup can be restored with LoadBasicGroup and will evoke exactly as it did when it was
+// saved.
+func (g *BasicGroup) Save(w io.Writer) error {
+	g.mu.RLock()
+	s, err := g.snapshot()
+	g.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	return writeSnapshot(w, BasicGroupType, s)
+}
+
+// LoadBasicGroup reads a BasicGroup from r which was previously written with BasicGroup.Save.
+func LoadBasicGroup(r io.Reader) (*BasicGroup, error) {
+	var s basicGroupSnapshot
+	if err := readSnapshot(r, BasicGroupType, &s); err != nil {
+		return nil, err
+	}
+	return s.restore()
+}
+
+// Save writes the complete state of the AdvancedGroup to w as a versioned Json snapshot. This includes both the
+// grandmother set and the component BasicGroup.
+func (g *AdvancedGroup) Save(w io.Writer) error {
+	g.grdMu.Lock()
+	g.BasicGroup.mu.RLock()
+	s, err := g.snapshot()
+	g.BasicGroup.mu.RUnlock()
+	g.grdMu.Unlock()
+	if err != nil {
+		return err
+	}
+	return writeSnapshot(w, AdvancedGroupType, s)
+}
+
+// LoadAdvancedGroup reads an AdvancedGroup from r which was previously written with AdvancedGroup.Save.
+func LoadAdvancedGroup(r io.Reader) (*AdvancedGroup, error) {
+	var s advancedGroupSnapshot
+	if err := readSnapshot(r, AdvancedGroupType, &s); err != nil {
+		return nil, err
+	}
+	return s.restore()
+}
+
+// Save writes the complete state of the SequenceGroup to w as a versioned Json snapshot. This includes the trace, so
+// a restored SequenceGroup continues the sequence it was following when it was saved.
+func (g *SequenceGroup) Save(w io.Writer) error {
+	g.traceMu.Lock()
+	g.BasicGroup.mu.RLock()
+	s, err := g.snapshot()
+	g.BasicGroup.mu.RUnlock()
+	g.traceMu.Unlock()
+	if err != nil {
+		return err
+	}
+	return writeSnapshot(w, SequenceGroupType, s)
+}
+
+// LoadSequenceGroup reads a SequenceGroup from r which was previously written with SequenceGroup.Save.
+func LoadSequenceGroup(r io.Reader) (*SequenceGroup, error) {
+	var s sequenceGroupSnapshot
+	if err := readSnapshot(r, SequenceGroupType, &s); err != nil {
+		return nil, err
+	}
+	return s.restore()
+}
+
+// Save writes the complete state of the ValenceGroup to w as a versioned Json snapshot.
+func (g *ValenceGroup) Save(w io.Writer) error {
+	g.BasicGroup.mu.RLock()
+	s, err := g.BasicGroup.snapshot()
+	g.BasicGroup.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	return writeSnapshot(w, ValenceGroupType, s)
+}
+
+// LoadValenceGroup reads a ValenceGroup from r which was previously written with ValenceGroup.Save.
+func LoadValenceGroup(r io.Reader) (*ValenceGroup, error) {
+	var s basicGroupSnapshot
+	if err := readSnapshot(r, ValenceGroupType, &s); err != nil {
+		return nil, err
+	}
+	bg, err := s.restore()
+	if err != nil {
+		return nil, err
+	}
+	return &ValenceGroup{BasicGroup: bg}, nil
+}
+
+// Load reads any Group from r which was previously written with Save. The type of the returned Group is determined
+// by the type tag of the snapshot.
+func Load(r io.Reader) (Group, error) {
+	groupType, payload, err := decodeSnapshot(r)
+	if err != nil {
+		return nil, err
+	}
+	switch groupType {
+	case BasicGroupType:
+		var s basicGroupSnapshot
+		if err := json.Unmarshal(payload, &s); err != nil {
+			return nil, err
+		}
+		g, err := s.restore()
+		if err != nil {
+			return nil, err
+		}
+		return g, nil
+	case AdvancedGroupType:
+		var s advancedGroupSnapshot
+		if err := json.Unmarshal(payload, &s); err != nil {
+			return nil, err
+		}
+		g, err := s.restore()
+		if err != nil {
+			return nil, err
+		}
+		return g, nil
+	case SequenceGroupType:
+		var s sequenceGroupSnapshot
+		if err := json.Unmarshal(payload, &s); err != nil {
+			return nil, err
+		}
+		g, err := s.restore()
+		if err != nil {
+			return nil, err
+		}
+		return g, nil
+	case ValenceGroupType:
+		var s basicGroupSnapshot
+		if err := json.Unmarshal(payload, &s); err != nil {
+			return nil, err
+		}
+		bg, err := s.restore()
+		if err != nil {
+			return nil, err
+		}
+		return &ValenceGroup{BasicGroup: bg}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnexpectedGroupType, groupType)
+	}
+}
+
+// writeSnapshot wraps the group snapshot, s, in a container of the current SchemaVersion and writes it to w.
+func writeSnapshot(w io.Writer, groupType string, s interface{}) error {
+	payload, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(container{
+		Format:        snapshotFormat,
+		SchemaVersion: SchemaVersion,
+		ModuleVersion: moduleVersion(),
+		Type:          groupType,
+		Group:         payload,
+	})
+}
+
+// readSnapshot reads a container from r, migrates it to the current SchemaVersion and decodes the group into s.
+func readSnapshot(r io.Reader, groupType string, s interface{}) error {
+	actualType, payload, err := decodeSnapshot(r)
+	if err != nil {
+		return err
+	}
+	if actualType != groupType {
+		return fmt.Errorf("%w: expected %q, found %q", ErrUnexpectedGroupType, groupType, actualType)
+	}
+	return json.Unmarshal(payload, s)
+}
+
+// decodeSnapshot reads a container from r and returns its group type and the group payload migrated to the current
+// SchemaVersion. Bare group Json without a container is treated as schema version 0.
+func decodeSnapshot(r io.Reader) (string, json.RawMessage, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return "", nil, err
+	}
+	var c container
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return "", nil, err
+	}
+	if c.Format != snapshotFormat {
+		c = container{SchemaVersion: 0, Type: legacyGroupType(raw), Group: raw}
+	}
+	if c.SchemaVersion > SchemaVersion {
+		return "", nil, fmt.Errorf("%w: version %d written by gracious %s, latest supported is %d",
+			ErrFutureSchemaVersion, c.SchemaVersion, c.ModuleVersion, SchemaVersion)
+	}
+	payload := c.Group
+	for version := c.SchemaVersion; version < SchemaVersion; version++ {
+		migrate, ok := migrations[version]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: version %d", ErrUnknownSchemaVersion, version)
+		}
+		var err error
+		if payload, err = migrate(c.Type, payload); err != nil {
+			return "", nil, fmt.Errorf("gracious: migrating snapshot from version %d: %w", version, err)
+		}
+	}
+	return c.Type, payload, nil
+}
+
+// legacyGroupType determines the group type of a version 0 snapshot, which carried no type tag. Only an
+// AdvancedGroup has grandmother neurons.
+func legacyGroupType(raw json.RawMessage) string {
+	var probe struct {
+		GrdNeurons json.RawMessage `json:"grdNeurons"`
+	}
+	if err := json.Unmarshal(raw, &probe); err == nil && probe.GrdNeurons != nil {
+		return AdvancedGroupType
+	}
+	return BasicGroupType
+}
+
+// moduleVersion returns the version of the gracious module compiled into the running binary, if it is known.
+func moduleVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if info.Main.Path == modulePath {
+			return info.Main.Version
+		}
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				return dep.Version
+			}
+		}
+	}
+	return "(devel)"
+}
+
+func (g *BasicGroup) snapshot() (basicGroupSnapshot, error) {
+	competition, err := snapshotCompetition(g.Competition)
+	if err != nil {
+		return basicGroupSnapshot{}, err
+	}
+	rule, err := snapshotLearningRule(g.LearningRule)
+	if err != nil {
+		return basicGroupSnapshot{}, err
+	}
+	s := basicGroupSnapshot{
+		Id:                   g.id,
+		PassThrough:          g.PassThrough,
+		WTA:                  g.WTA,
+		Competition:          competition,
+		CorrelationThreshold: g.CorrelationThreshold,
+		MismatchInhibition:   g.MismatchInhibition,
+		UnlearnOnMismatch:    g.UnlearnOnMismatch,
+		CorrelationDecay:     g.CorrelationDecay,
+		WeightCap:            g.WeightCap,
+		LearningRule:         rule,
+		Frozen:               g.frozen,
+		Evaluation:           g.Evaluation,
+		Workers:              g.Workers,
+		Pattern:              snapshotSignal(g.pattern),
+		Neurons:              make([]neuronSnapshot, 0, len(g.neurons)),
+	}
+	if g.learningMask != nil {
+		mask := NewQualitativeSignal(g.id + "-mask")
+		for addr := range g.learningMask {
+			mask.Features[addr] = 1
+		}
+		ms := snapshotSignal(mask)
+		s.LearningMask = &ms
+	}
+	for addr, n := range g.neurons {
+		ns := n.snapshot()
+		ns.Address = addr
+		s.Neurons = append(s.Neurons, ns)
+	}
+	sort.Slice(s.Neurons, func(i, j int) bool {
+		return addressLess(s.Neurons[i].Address, s.Neurons[j].Address)
+	})
+	return s, nil
+}
+
+func (s basicGroupSnapshot) restore() (*BasicGroup, error) {
+	competition, err := s.Competition.restore()
+	if err != nil {
+		return nil, err
+	}
+	rule, err := s.LearningRule.restore()
+	if err != nil {
+		return nil, err
+	}
+	g := NewBasicGroup(s.Id)
+	g.PassThrough = s.PassThrough
+	g.WTA = s.WTA
+	g.Competition = competition
+	g.CorrelationThreshold = s.CorrelationThreshold
+	g.MismatchInhibition = s.MismatchInhibition
+	g.UnlearnOnMismatch = s.UnlearnOnMismatch
+	g.CorrelationDecay = s.CorrelationDecay
+	g.WeightCap = s.WeightCap
+	g.LearningRule = rule
+	g.frozen = s.Frozen
+	if s.LearningMask != nil {
+		g.learningMask = make(map[Address]bool)
+		for _, fs := range s.LearningMask.Features {
+			g.learningMask[fs.Address] = true
+		}
+	}
+	g.Evaluation = s.Evaluation
+	g.Workers = s.Workers
+	g.pattern = s.Pattern.restore()
+	for _, ns := range s.Neurons {
+		g.neurons[ns.Address] = ns.restore()
+	}
+	return g, nil
+}
+
+func (g *AdvancedGroup) snapshot() (advancedGroupSnapshot, error) {
+	bs, err := g.BasicGroup.snapshot()
+	if err != nil {
+		return advancedGroupSnapshot{}, err
+	}
+	s := advancedGroupSnapshot{
+		basicGroupSnapshot:      bs,
+		GrdCorrelationThreshold: g.GrdCorrelationThreshold,
+		GrdNeurons:              make([]neuronSnapshot, len(g.grdNeurons)),
+	}
+	for i, n := range g.grdNeurons {
+		s.GrdNeurons[i] = n.snapshot()
+	}
+	return s, nil
+}
+
+func (s advancedGroupSnapshot) restore() (*AdvancedGroup, error) {
+	if len(s.GrdNeurons) == 0 {
+		return nil, ErrNoGrandmotherNeurons
+	}
+	bg, err := s.basicGroupSnapshot.restore()
+	if err != nil {
+		return nil, err
+	}
+	g := AdvancedGroup{
+		grdNeurons:              make([]*neuron, len(s.GrdNeurons)),
+		GrdCorrelationThreshold: s.GrdCorrelationThreshold,
+		BasicGroup:              bg,
+	}
+	for i, ns := range s.GrdNeurons {
+		g.grdNeurons[i] = ns.restore()
+	}
+	return &g, nil
+}
+
+func (g *SequenceGroup) snapshot() (sequenceGroupSnapshot, error) {
+	bs, err := g.BasicGroup.snapshot()
+	if err != nil {
+		return sequenceGroupSnapshot{}, err
+	}
+	return sequenceGroupSnapshot{
+		basicGroupSnapshot: bs,
+		Trace:              snapshotSignal(g.trace),
+		TraceStrength:      g.TraceStrength,
+		TraceDecay:         g.TraceDecay,
+		TraceOffset:        g.TraceOffset,
+	}, nil
+}
+
+func (s sequenceGroupSnapshot) restore() (*SequenceGroup, error) {
+	bg, err := s.basicGroupSnapshot.restore()
+	if err != nil {
+		return nil, err
+	}
+	return &SequenceGroup{
+		trace:         s.Trace.restore(),
+		TraceStrength: s.TraceStrength,
+		TraceDecay:    s.TraceDecay,
+		TraceOffset:   s.TraceOffset,
+		BasicGroup:    bg,
+	}, nil
+}
+
+// snapshotCompetition returns the persisted form of the Competition, or nil if there is no Competition.
+func snapshotCompetition(c Competition) (*competitionSnapshot, error) {
+	switch c := c.(type) {
+	case nil:
+		return nil, nil
+	case MaxGap:
+		return &competitionSnapshot{Kind: "MaxGap", Parameter: c.Gap}, nil
+	case TopK:
+		return &competitionSnapshot{Kind: "TopK", Parameter: c.K}, nil
+	case Percentile:
+		return &competitionSnapshot{Kind: "Percentile", Parameter: c.Percent}, nil
+	case PerColumn:
+		return &competitionSnapshot{Kind: "PerColumn", Parameter: c.Gap}, nil
+	case PerRow:
+		return &competitionSnapshot{Kind: "PerRow", Parameter: c.Gap}, nil
+	case SoftNormalize:
+		return &competitionSnapshot{Kind: "SoftNormalize", Parameter: c.Scale}, nil
+	case LateralInhibition:
+		s := competitionSnapshot{Kind: "LateralInhibition", Parameter: c.Strength, Radius: c.Radius}
+		if c.Kernel != nil {
+			kernel := snapshotSignal(QualitativeSignal{Features: c.Kernel}).Features
+			s.Kernel = &kernel
+		}
+		return &s, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedCompetition, c)
+	}
+}
+
+func (s *competitionSnapshot) restore() (Competition, error) {
+	if s == nil {
+		return nil, nil
+	}
+	switch s.Kind {
+	case "MaxGap":
+		return MaxGap{Gap: s.Parameter}, nil
+	case "TopK":
+		return TopK{K: s.Parameter}, nil
+	case "Percentile":
+		return Percentile{Percent: s.Parameter}, nil
+	case "PerColumn":
+		return PerColumn{Gap: s.Parameter}, nil
+	case "PerRow":
+		return PerRow{Gap: s.Parameter}, nil
+	case "SoftNormalize":
+		return SoftNormalize{Scale: s.Parameter}, nil
+	case "LateralInhibition":
+		l := LateralInhibition{Radius: s.Radius, Strength: s.Parameter}
+		if s.Kernel != nil {
+			l.Kernel = signalSnapshot{Features: *s.Kernel}.restore().Features
+		}
+		return l, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrUnsupportedCompetition, s.Kind)
+	}
+}
+
+// snapshotLearningRule returns the persisted form of the LearningRule, or nil if the group uses the default rule.
+func snapshotLearningRule(r LearningRule) (*learningRuleSnapshot, error) {
+	switch r := r.(type) {
+	case nil:
+		return nil, nil
+	case CorrelativeHebbian:
+		return &learningRuleSnapshot{Kind: "CorrelativeHebbian"}, nil
+	case ClassicalHebb:
+		return &learningRuleSnapshot{Kind: "ClassicalHebb"}, nil
+	case Oja:
+		return &learningRuleSnapshot{Kind: "Oja", Parameter: r.Normalization}, nil
+	case Covariance:
+		return &learningRuleSnapshot{Kind: "Covariance"}, nil
+	case BCM:
+		return &learningRuleSnapshot{Kind: "BCM"}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedLearningRule, r)
+	}
+}
+
+func (s *learningRuleSnapshot) restore() (LearningRule, error) {
+	if s == nil {
+		return nil, nil
+	}
+	switch s.Kind {
+	case "CorrelativeHebbian":
+		return CorrelativeHebbian{}, nil
+	case "ClassicalHebb":
+		return ClassicalHebb{}, nil
+	case "Oja":
+		return Oja{Normalization: s.Parameter}, nil
+	case "Covariance":
+		return Covariance{}, nil
+	case "BCM":
+		return BCM{}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrUnsupportedLearningRule, s.Kind)
+	}
+}
+
+func (n *neuron) snapshot() neuronSnapshot {
+	s := neuronSnapshot{
+		Synapses:        make([]synapseSnapshot, 0, len(n.synapses)),
+		Axon:            n.axon,
+		Match:           n.match,
+		Novelty:         n.novelty,
+		LearningEnabled: n.learningEnabled,
+		Average:         n.average,
+	}
+	for addr, syn := range n.synapses {
+		s.Synapses = append(s.Synapses, synapseSnapshot{
+			Address:        addr,
+			CorrelationSum: syn.correlationSum,
+			InhibitionSum:  syn.inhibitionSum,
+			WeightValue:    syn.weightValue,
+			Average:        syn.average,
+		})
+	}
+	sort.Slice(s.Synapses, func(i, j int) bool {
+		return addressLess(s.Synapses[i].Address, s.Synapses[j].Address)
+	})
+	return s
+}
+
+func (s neuronSnapshot) restore() *neuron {
+	n := newNeuron()
+	n.axon = s.Axon
+	n.match = s.Match
+	n.novelty = s.Novelty
+	n.learningEnabled = s.LearningEnabled
+	n.average = s.Average
+	for _, ss := range s.Synapses {
+		n.synapses[ss.Address] = &Synapse{
+			correlationSum: ss.CorrelationSum,
+			inhibitionSum:  ss.InhibitionSum,
+			weightValue:    ss.WeightValue,
+			average:        ss.Average,
+		}
+	}
+	return n
+}
+
+func snapshotSignal(q QualitativeSignal) signalSnapshot {
+	s := signalSnapshot{
+		Id:       q.Id,
+		Novelty:  q.Novelty,
+		MisMatch: q.MisMatch,
+		Features: make([]featureSnapshot, 0, len(q.Features)),
+	}
+	for addr, feature := range q.Features {
+		s.Features = append(s.Features, featureSnapshot{Address: addr, Value: feature})
+	}
+	sort.Slice(s.Features, func(i, j int) bool {
+		return addressLess(s.Features[i].Address, s.Features[j].Address)
+	})
+	return s
+}
+
+func (s signalSnapshot) restore() QualitativeSignal {
+	q := QualitativeSignal{Id: s.Id, Novelty: s.Novelty, MisMatch: s.MisMatch, Features: make(map[Address]int)}
+	for _, fs := range s.Features {
+		q.Features[fs.Address] = fs.Value
+	}
+	return q
+}
+
+// addressLess orders Address values by Z, then X, then Y, so that snapshots are written deterministically.
+func addressLess(a, b Address) bool {
+	if a.Z != b.Z {
+		return a.Z < b.Z
+	}
+	if a.X != b.X {
+		return a.X < b.X
+	}
+	return a.Y < b.Y
+}
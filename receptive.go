@@ -0,0 +1,123 @@
+package gracious
+
+import (
+	"sync"
+)
+
+// A ReceptiveField maps a QualitativeSignal with an image-like geometry onto a QualitativeSignal of local feature
+// detections. Each kernel of the ReceptiveField is a small pattern of weights, given by the offset of each weight from
+// the position of the kernel. The kernels are laid over the X,Y plane of the input at every Stride columns and rows,
+// and wherever the weighted sum of the input features under a kernel reaches Threshold the kernel has detected its
+// feature at that position.
+//
+// Detections are pooled over blocks of Pool by Pool positions, keeping the strongest detection of each kernel within
+// a block, so that a feature moved within a block is detected at the same output Address. Placing a ReceptiveField
+// with a large Pool in front of a BasicGroup therefore lets the group recognize a pattern regardless of where it lies.
+//
+// The kernels are laid over layer 0 of the input, but a kernel can reach into other layers, such as the color channels
+// of an image, through the Z of its offsets. The detections of each kernel are placed on a layer of their own, so the
+// detection of kernel k in the pooled block at column x and row y is the feature at Address{X: x, Y: y, Z: k}.
+type ReceptiveField struct {
+	mu        sync.RWMutex      // Guards the kernels
+	id        string            // The name of this ReceptiveField
+	kernels   []map[Address]int // The weights of each kernel, by offset from the position of the kernel
+	Threshold int               // Determines the weighted sum a kernel must reach to detect its feature
+	Stride    int               // Determines the distance between the positions of the kernels
+	Pool      int               // Determines the size of the blocks of positions detections are pooled over
+}
+
+// NewReceptiveField returns a new ReceptiveField with no kernels, which lays its kernels at every position and does
+// not pool its detections.
+func NewReceptiveField(id string) *ReceptiveField {
+	f := ReceptiveField{id: id, Threshold: 1, Stride: 1, Pool: 1}
+	return &f
+}
+
+// GetId returns the id of this ReceptiveField
+func (f *ReceptiveField) GetId() string {
+	return f.id
+}
+
+// AddKernel adds a fixed kernel to the ReceptiveField and returns its index. The kernel holds a weight for each
+// offset from the position of the kernel. Negative weights make a kernel respond less to features at their offset.
+func (f *ReceptiveField) AddKernel(kernel map[Address]int) int {
+	copied := make(map[Address]int, len(kernel))
+	for offset, weight := range kernel {
+		copied[offset] = weight
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.kernels = append(f.kernels, copied)
+	return len(f.kernels) - 1
+}
+
+// Learn imprints the exemplar as a new kernel of the ReceptiveField and returns its index. The features of the
+// exemplar become the weights of the kernel, with the top left corner of the lowest layer of the exemplar as the
+// position of the kernel.
+func (f *ReceptiveField) Learn(exemplar QualitativeSignal) int {
+	origin, _, _ := exemplar.Bounds()
+	return f.AddKernel(exemplar.Translate(Address{X: -origin.X, Y: -origin.Y, Z: -origin.Z}).Features)
+}
+
+// Kernels returns the number of kernels of the ReceptiveField.
+func (f *ReceptiveField) Kernels() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.kernels)
+}
+
+// Evoke returns the pooled feature detections of every kernel of the ReceptiveField over the input.
+func (f *ReceptiveField) Evoke(input QualitativeSignal) QualitativeSignal {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	stride, pool := f.Stride, f.Pool
+	if stride < 1 {
+		stride = 1
+	}
+	if pool < 1 {
+		pool = 1
+	}
+	// Accumulate the response of each kernel at every position under an input feature
+	type detector struct {
+		position Address
+		kernel   int
+	}
+	responses := make(map[detector]int)
+	for addr, feature := range input.Features {
+		for k, kernel := range f.kernels {
+			for offset, weight := range kernel {
+				position := Address{X: addr.X - offset.X, Y: addr.Y - offset.Y, Z: addr.Z - offset.Z}
+				if position.Z == 0 && floorMod(position.X, stride) == 0 && floorMod(position.Y, stride) == 0 {
+					responses[detector{position, k}] += feature * weight
+				}
+			}
+		}
+	}
+	output := NewQualitativeSignal(f.id)
+	for d, response := range responses {
+		if response <= 0 || response < f.Threshold {
+			continue
+		}
+		x := floorDiv(floorDiv(d.position.X, stride), pool)
+		y := floorDiv(floorDiv(d.position.Y, stride), pool)
+		addr := Address{X: x, Y: y, Z: d.kernel}
+		if response > output.Features[addr] {
+			output.Features[addr] = response
+		}
+	}
+	return output
+}
+
+// floorDiv divides a by b, rounding towards negative infinity, so that negative coordinates pool like positive ones.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// floorMod returns the remainder of floorDiv.
+func floorMod(a, b int) int {
+	return a - floorDiv(a, b)*b
+}
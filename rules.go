@@ -0,0 +1,148 @@
+package gracious
+
+// ActivityScale is the fixed point scale of running averages of activity. An Activity.Average or Synapse.Average of
+// ActivityScale means the activity has averaged 1.
+const ActivityScale = 256
+
+// activityRate is the number of evocations over which a running average of activity mostly adapts to a change.
+const activityRate = 8
+
+// runningAverage returns the exponential running average, scaled by ActivityScale, after observing the value.
+func runningAverage(average, value int) int {
+	return average + (value*ActivityScale-average)/activityRate
+}
+
+// An Activity describes an evocation of a neuron to the LearningRule training one of its synapses, along with the
+// learning settings of the Group the neuron belongs to.
+type Activity struct {
+	Association int  // The value of the association feature at the synapse, or 0 if it is not active
+	Training    int  // The value of the main feature at the neuron, the activity the neuron is taught
+	Sum         int  // The weighted sum of the active synapses, the activity the association evokes in the neuron
+	Average     int  // The running average of the training of the neuron before this evocation, scaled by ActivityScale
+	Correlation int  // The correlation threshold of the Group
+	Cap         int  // The strongest graded weight of the Group, or 0 for bipolar weights
+	Unlearn     bool // Whether the Group unlearns excitatory synapses on mismatch
+	Reinforce   bool // Whether the Group reinforces excitatory synapses in use
+}
+
+// A LearningRule trains the synapses of a neuron after each evocation. Learn is called for every synapse whose
+// association feature is active, and also for every inactive synapse if TrainsInactive reports true. A BasicGroup uses
+// the CorrelativeHebbian rule unless another LearningRule is selected.
+type LearningRule interface {
+	Learn(syn *Synapse, a Activity)
+	TrainsInactive() bool
+}
+
+// CorrelativeHebbian is the default LearningRule of Gracious. A neuron which does not fire for positive training
+// trains its active synapses with the +3:-1 steps of Synapse.Train. A neuron which fires against negative training
+// inhibits the association, or unlearns it if the Group unlearns on mismatch. When the Group reinforces synapses in
+// use, a neuron which fires for positive training keeps raising the correlation of its excitatory synapses.
+type CorrelativeHebbian struct{}
+
+// Learn trains the synapse with the correlative Hebbian rule.
+func (CorrelativeHebbian) Learn(syn *Synapse, a Activity) {
+	if (a.Sum <= 0) && (a.Training > 0) {
+		syn.Train(a.Training, a.Association, a.Correlation)
+	} else if (a.Sum > 0) && (a.Training > 0) && a.Reinforce {
+		syn.reinforce(a.Training, a.Association)
+	} else if (a.Sum > 0) && (a.Training < 0) {
+		if a.Unlearn && syn.excitatory() {
+			syn.Unlearn(a.Training, a.Association, a.Correlation)
+		} else {
+			syn.Inhibit(a.Training, a.Association, a.Correlation)
+		}
+	}
+	if a.Cap > 0 {
+		syn.grade(a.Correlation, a.Cap)
+	}
+}
+
+// TrainsInactive reports false, as only active synapses are trained.
+func (CorrelativeHebbian) TrainsInactive() bool {
+	return false
+}
+
+// ClassicalHebb raises the correlation of a synapse by the product of its association and the training of its neuron
+// whenever both are active, whether the neuron fires or not. The correlation is never lowered.
+type ClassicalHebb struct{}
+
+// Learn trains the synapse with the classical Hebbian rule.
+func (ClassicalHebb) Learn(syn *Synapse, a Activity) {
+	if a.Association > 0 && a.Training > 0 {
+		syn.Adjust(a.Association*a.Training, a.Correlation, a.Cap)
+	}
+}
+
+// TrainsInactive reports false, as an inactive synapse has no product to learn.
+func (ClassicalHebb) TrainsInactive() bool {
+	return false
+}
+
+// Oja normalizes Hebbian learning so that the correlation of a synapse converges rather than growing without bound.
+// Whenever its neuron is trained, a synapse gains 4 times the product of its association and the training, and loses
+// the square of the training times its correlation over Normalization. A synapse which is always active with its
+// neuron settles at a correlation of 4 * Normalization, while the synapses of a trained neuron which are not active
+// fade.
+type Oja struct {
+	Normalization int // Determines the correlation a synapse settles at, along with its association
+}
+
+// Learn trains the synapse with the normalized rule.
+func (o Oja) Learn(syn *Synapse, a Activity) {
+	if a.Training == 0 {
+		return
+	}
+	normalization := o.Normalization
+	if normalization < 1 {
+		normalization = 1
+	}
+	y := a.Training
+	syn.Adjust(y*(4*a.Association-y*syn.CorrelationSum()/normalization), a.Correlation, a.Cap)
+}
+
+// TrainsInactive reports true, as the synapses of a trained neuron which are not active fade.
+func (Oja) TrainsInactive() bool {
+	return true
+}
+
+// Covariance raises the correlation of a synapse when its association and the training of its neuron are both above
+// their running averages or both below them, and lowers it when one is above and the other below. Associations which
+// are always present, and so carry no information about the training, are not learnt. A fully novel coincidence raises
+// the correlation by 4, the same step as Synapse.Train.
+type Covariance struct{}
+
+// Learn trains the synapse with the covariance rule.
+func (Covariance) Learn(syn *Synapse, a Activity) {
+	x := a.Association*ActivityScale - syn.Average()
+	y := a.Training*ActivityScale - a.Average
+	syn.Observe(a.Association)
+	syn.Adjust(4*x*y/(ActivityScale*ActivityScale), a.Correlation, a.Cap)
+}
+
+// TrainsInactive reports true, as an inactive association is below its average.
+func (Covariance) TrainsInactive() bool {
+	return true
+}
+
+// BCM is a Hebbian rule with a sliding threshold. A synapse which is active while its neuron is trained gains in
+// proportion to how far the training lies above the running average of the neuron's training, and loses in
+// proportion to how far it lies below. A neuron which is trained constantly therefore stops strengthening its
+// synapses, while a neuron which is trained rarely learns quickly. Negative training always lowers the correlation.
+type BCM struct{}
+
+// Learn trains the synapse with the sliding threshold rule.
+func (BCM) Learn(syn *Synapse, a Activity) {
+	if a.Association <= 0 || a.Training == 0 {
+		return
+	}
+	if a.Training < 0 {
+		syn.Adjust(4*a.Association*a.Training, a.Correlation, a.Cap)
+		return
+	}
+	syn.Adjust(4*a.Association*a.Training*(a.Training*ActivityScale-a.Average)/ActivityScale, a.Correlation, a.Cap)
+}
+
+// TrainsInactive reports false, as an inactive synapse does not change.
+func (BCM) TrainsInactive() bool {
+	return false
+}
@@ -0,0 +1,105 @@
+package gracious
+
+import (
+	"sync"
+)
+
+// A SequenceGroup learns ordered chains of signals. Each evocation feeds the firing pattern of the previous evocation
+// back into the group as part of the association signal, so the group learns to associate each main signal with the
+// signal that came before it. Once a sequence has been learnt, presenting its first elements evokes the continuation
+// one element per evocation, even in the absence of a main signal.
+//
+// The fed back signal is a decaying trace. Each firing pattern enters the trace with a strength of TraceStrength and
+// loses TraceDecay with every following evocation, so a TraceDecay below TraceStrength lets a SequenceGroup take more
+// than the last element into account. The trace is moved by TraceOffset before it is joined to the association signal
+// so that it never overlaps the external association. By default the trace is moved to layer 1.
+type SequenceGroup struct {
+	traceMu       sync.Mutex        // Serializes evocation and guards the trace
+	trace         QualitativeSignal // The decaying trace of the previous firing patterns
+	TraceStrength int               // Determines the strength of a firing pattern as it enters the trace
+	TraceDecay    int               // Determines how much the trace weakens with each evocation
+	TraceOffset   Address           // Determines where the trace is placed in the association signal
+	*BasicGroup                     // The component BasicGroup
+}
+
+// NewSequenceGroup returns a new SequenceGroup instance with an empty trace. The component BasicGroup passes the main
+// signal through, so that the elements of a sequence enter the trace while it is being learnt.
+func NewSequenceGroup(id string) *SequenceGroup {
+	g := SequenceGroup{
+		trace:         NewQualitativeSignal(id + "-trace"),
+		TraceStrength: 1,
+		TraceDecay:    1,
+		TraceOffset:   Address{Z: 1},
+	}
+	g.BasicGroup = NewBasicGroup(id)
+	g.BasicGroup.PassThrough = true
+	return &g
+}
+
+// Evoke will test the SequenceGroup for an associative evocation pattern. The association signal is joined with the
+// trace of the previous firing patterns before the component BasicGroup is evoked, and the resulting firing pattern
+// is added to the trace. Concurrent calls to Evoke are serialized.
+func (g *SequenceGroup) Evoke(main, association QualitativeSignal) QualitativeSignal {
+	return g.evoke(main, association, 1)
+}
+
+// EvokeGated behaves exactly as Evoke, but the SequenceGroup only learns when the gate signal holds a positive
+// feature. The trace follows the firing patterns whether or not the gate is open.
+func (g *SequenceGroup) EvokeGated(main, association, gate QualitativeSignal) QualitativeSignal {
+	return g.evoke(main, association, gateModulation(gate))
+}
+
+// EvokeModulated behaves exactly as Evoke, but the training of the SequenceGroup is modulated as by
+// BasicGroup.EvokeModulated.
+func (g *SequenceGroup) EvokeModulated(main, association, modulation QualitativeSignal) QualitativeSignal {
+	return g.evoke(main, association, modulationLevel(modulation))
+}
+
+// evoke evokes the component BasicGroup with the association joined with the trace and the training modulated by
+// the level.
+func (g *SequenceGroup) evoke(main, association QualitativeSignal, modulation int) QualitativeSignal {
+	g.traceMu.Lock()
+	defer g.traceMu.Unlock()
+	combined := NewQualitativeSignal(association.Id)
+	combined.Composite(association, g.trace.Translate(g.TraceOffset))
+	pattern := g.BasicGroup.evokeAssociation(main, combined, modulation)
+	trace := NewQualitativeSignal(g.id + "-trace")
+	for addr, feature := range g.trace.Features {
+		if feature -= g.TraceDecay; feature > 0 {
+			trace.Features[addr] = feature
+		}
+	}
+	for addr := range pattern.Features {
+		if trace.Features[addr] < g.TraceStrength {
+			trace.Features[addr] = g.TraceStrength
+		}
+	}
+	g.trace = trace
+	return pattern
+}
+
+// EvokeDense behaves exactly as Evoke. The DenseSignal is converted into a QualitativeSignal, as the trace of the
+// SequenceGroup lies outside the bounding box of the association signal.
+func (g *SequenceGroup) EvokeDense(main QualitativeSignal, association DenseSignal) QualitativeSignal {
+	return g.Evoke(main, association.ToQualitativeSignal())
+}
+
+// AsyncEvoke will Evoke this Group as a member of a WaitGroup
+func (g *SequenceGroup) AsyncEvoke(main, association QualitativeSignal, wg *sync.WaitGroup) QualitativeSignal {
+	defer wg.Done()
+	return g.Evoke(main, association)
+}
+
+// GetTrace returns the current trace of the previous firing patterns, before it is moved by TraceOffset.
+func (g *SequenceGroup) GetTrace() QualitativeSignal {
+	g.traceMu.Lock()
+	defer g.traceMu.Unlock()
+	return g.trace.Clone()
+}
+
+// Reset clears the trace so that the next evocation begins a new sequence.
+func (g *SequenceGroup) Reset() {
+	g.traceMu.Lock()
+	defer g.traceMu.Unlock()
+	g.trace = NewQualitativeSignal(g.id + "-trace")
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"testing"
+)
+
+func TestDecodingActuator(t *testing.T) {
+	colorJSA := io.JsonFromFileName("data/colorA.json")
+	wordJSA := io.JsonFromFileName("data/wordA.json")
+	name, training := "", true
+	colors := io.NewFunctionalSensor("colors", func() gracious.QualitativeSignal {
+		return colorJSA.GetJsonSignalById(name).ToDistributedSignal()
+	})
+	words := io.NewFunctionalSensor("words", func() gracious.QualitativeSignal {
+		if !training {
+			return gracious.NewQualitativeSignal("silence")
+		}
+		return wordJSA.GetJsonSignalById(name).ToDistributedSignal()
+	})
+	said := ""
+	mouth := io.NewDecodingActuator("mouth", wordJSA, func(word io.JsonSignal) {
+		said = word.Id
+	})
+	wordGroup := gracious.NewBasicGroup("wordGroup")
+	wordGroup.CorrelationThreshold = 5
+	wordGroup.WTA = -1
+	network := gracious.NewNetwork("speaker")
+	for _, err := range []error{
+		network.AddSource(colors),
+		network.AddSource(words),
+		network.AddGroup(wordGroup),
+		network.AddOutput(mouth),
+		network.Connect("words", "wordGroup", gracious.MainPort),
+		network.Connect("colors", "wordGroup", gracious.AssociationPort),
+		network.Connect("wordGroup", "mouth", gracious.MainPort),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, color := range colorNames {
+		name = color
+		for i := 0; i < 6; i++ {
+			network.Step()
+		}
+	}
+	training = false
+	for _, color := range colorNames {
+		name, said = color, ""
+		network.Step()
+		if said != color {
+			t.Errorf("expected %q to be said, got %q", color, said)
+		}
+	}
+	if _, ok := wordJSA.BestMatch(gracious.NewQualitativeSignal("void")); ok {
+		t.Errorf("expected an empty signal to match nothing")
+	}
+}
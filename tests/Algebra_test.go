@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"github.com/Art-of-the-Living/gracious"
+	"testing"
+)
+
+func TestAlgebra(t *testing.T) {
+	a := signalOf("a", map[int]int{0: 1, 1: 5, 2: 3})
+	b := signalOf("b", map[int]int{1: 2, 2: 7, 3: 4})
+	original := signalOf("original", map[int]int{0: 1, 1: 5, 2: 3})
+	for _, c := range []struct {
+		name     string
+		actual   gracious.QualitativeSignal
+		expected gracious.QualitativeSignal
+	}{
+		{"Intersect", a.Intersect(b), signalOf("", map[int]int{1: 2, 2: 3})},
+		{"Difference", a.Difference(b), signalOf("", map[int]int{0: 1})},
+		{"Mask", a.Mask(b), signalOf("", map[int]int{1: 5, 2: 3})},
+		{"Threshold", a.Threshold(3), signalOf("", map[int]int{1: 5, 2: 3})},
+		{"Clamp", a.Clamp(2, 4), signalOf("", map[int]int{0: 2, 1: 4, 2: 3})},
+		{"ClampToZero", a.Clamp(-1, 0), signalOf("", map[int]int{})},
+		{"Translate", a.Translate(gracious.Address{X: 2}), signalOf("", map[int]int{2: 1, 3: 5, 4: 3})},
+		{"Crop", a.Crop(gracious.Address{X: 1}, 5, 1), signalOf("", map[int]int{1: 5, 2: 3})},
+	} {
+		if !sameFeatures(c.expected, c.actual) {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected.Represent(), c.actual.Represent())
+		}
+		if c.actual.Id != a.Id {
+			t.Errorf("%s: expected the result to keep the id %q, got %q", c.name, a.Id, c.actual.Id)
+		}
+	}
+	if !sameFeatures(original, a) {
+		t.Errorf("expected the operations to leave the signal unchanged, got %s", a.Represent())
+	}
+	pipeline := a.Translate(gracious.Address{X: 1}).Threshold(2)
+	pipeline.Features[gracious.Address{X: 9}] = 1
+	if _, ok := a.Features[gracious.Address{X: 9}]; ok {
+		t.Errorf("expected the result of a pipeline not to share features with its input")
+	}
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"testing"
+)
+
+// Before firing patterns were cloned, a PassThrough group used the caller's main signal as its firing pattern and
+// added the firing strength of its neurons into it, corrupting the caller's input.
+func TestPassThroughDoesNotCorruptMain(t *testing.T) {
+	colorJSA := io.JsonFromFileName("data/colorA.json")
+	wordJSA := io.JsonFromFileName("data/wordA.json")
+	bg := gracious.NewBasicGroup("passThroughGroup")
+	bg.CorrelationThreshold = 1
+	bg.PassThrough = true
+	bg.WTA = -1
+	red := colorJSA.GetJsonSignalById("red").ToDistributedSignal()
+	word := wordJSA.GetJsonSignalById("red").ToDistributedSignal()
+	original := red.Clone()
+	for i := 0; i < 6; i++ {
+		bg.Evoke(red, word)
+	}
+	if !sameFeatures(original, red) {
+		t.Errorf("expected the main signal to be unchanged, got %s", red.Represent())
+	}
+	if evocation := bg.GetFirePattern(); sameFeatures(original, evocation) {
+		t.Errorf("expected the learnt association to strengthen the firing pattern, got %s", evocation.Represent())
+	}
+}
+
+// Before firing patterns were cloned, the signal returned from Evoke was the group's own firing pattern, so
+// modifying it, as a WinnerTakesAll does, changed what GetFirePattern reported.
+func TestEvocationDoesNotAliasFirePattern(t *testing.T) {
+	colorJSA := io.JsonFromFileName("data/colorA.json")
+	wordJSA := io.JsonFromFileName("data/wordA.json")
+	bg := gracious.NewBasicGroup("aliasGroup")
+	bg.CorrelationThreshold = 5
+	train(bg, colorJSA, wordJSA, 6)
+	evocation := bg.Evoke(gracious.NewQualitativeSignal("void"), wordJSA.GetJsonSignalById("red").ToDistributedSignal())
+	expected := evocation.Clone()
+	evocation.Features[gracious.Address{X: 9, Y: 9}] = 1
+	if fired := bg.GetFirePattern(); !sameFeatures(expected, fired) {
+		t.Errorf("expected the fire pattern to be %s, got %s", expected.Represent(), fired.Represent())
+	}
+	clone := expected.Clone()
+	clone.Features[gracious.Address{X: 9, Y: 9}] = 1
+	if _, ok := expected.Features[gracious.Address{X: 9, Y: 9}]; ok {
+		t.Errorf("expected a clone not to share its features with the original")
+	}
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"testing"
+)
+
+// runLoop builds two groups which each take the output of the other as their association, registers the components
+// with a Clock in the given order, and returns the firing patterns of both groups after every tick.
+func runLoop(reversed bool) []gracious.QualitativeSignal {
+	colorJSA := io.JsonFromFileName("data/colorA.json")
+	wordJSA := io.JsonFromFileName("data/wordA.json")
+	name := ""
+	colors := gracious.NewClockedSource(io.NewFunctionalSensor("colors", func() gracious.QualitativeSignal {
+		return colorJSA.GetJsonSignalById(name).ToDistributedSignal()
+	}))
+	words := gracious.NewClockedSource(io.NewFunctionalSensor("words", func() gracious.QualitativeSignal {
+		return wordJSA.GetJsonSignalById(name).ToDistributedSignal()
+	}))
+	colorGroup := gracious.NewBasicGroup("colorGroup")
+	colorGroup.CorrelationThreshold = 3
+	colorGroup.PassThrough = true
+	wordGroup := gracious.NewBasicGroup("wordGroup")
+	wordGroup.CorrelationThreshold = 3
+	wordGroup.PassThrough = true
+	a := gracious.NewClockedGroup(colorGroup)
+	b := gracious.NewClockedGroup(wordGroup)
+	a.Connect(colors, gracious.MainPort)
+	a.Connect(b, gracious.AssociationPort)
+	b.Connect(words, gracious.MainPort)
+	b.Connect(a, gracious.AssociationPort)
+	clock := gracious.NewClock()
+	if reversed {
+		clock.Register(words, colors, b, a)
+	} else {
+		clock.Register(a, b, colors, words)
+	}
+	var patterns []gracious.QualitativeSignal
+	for _, color := range append(colorNames, "", "", "") {
+		name = color
+		for i := 0; i < 4; i++ {
+			clock.Tick()
+			patterns = append(patterns, a.Published(), b.Published())
+		}
+	}
+	return patterns
+}
+
+func TestClockIsDeterministic(t *testing.T) {
+	expected := runLoop(false)
+	for i := 0; i < 4; i++ {
+		actual := runLoop(i%2 == 0)
+		for j := range expected {
+			if !sameFeatures(expected[j], actual[j]) {
+				t.Fatalf("tick %d: expected %s, got %s", j/2+1, expected[j].Represent(), actual[j].Represent())
+			}
+		}
+	}
+}
+
+func TestClockedNetwork(t *testing.T) {
+	signal := gracious.NewQualitativeSignal("pulse")
+	signal.Features[gracious.Address{X: 0, Y: 0}] = 1
+	pulse := io.NewFunctionalSensor("pulse", func() gracious.QualitativeSignal {
+		return signal
+	})
+	network := gracious.NewNetwork("delayLine")
+	relay := &recorder{id: "relay"}
+	sink := &recorder{id: "sink"}
+	for _, err := range []error{
+		network.AddSource(pulse),
+		network.AddOutput(relay),
+		network.AddOutput(sink),
+		network.Connect("pulse", "relay", gracious.MainPort),
+		network.Connect("relay", "sink", gracious.MainPort),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	clock := gracious.NewClock()
+	clock.Register(network)
+	for tick := 1; tick <= 3; tick++ {
+		clock.Tick()
+		relayed := len(relay.signals[tick-1].Features) > 0
+		sunk := len(sink.signals[tick-1].Features) > 0
+		if relayed != (tick >= 2) || sunk != (tick >= 3) {
+			t.Errorf("tick %d: expected the pulse to advance one node per tick", tick)
+		}
+	}
+	if clock.Now() != 3 {
+		t.Errorf("expected the clock to be at tick 3, got %d", clock.Now())
+	}
+}
@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"github.com/Art-of-the-Living/gracious"
+	"math"
+	"testing"
+)
+
+// signalOf returns a QualitativeSignal with a feature of the given value at each Address along the X axis
+func signalOf(name string, values map[int]int) gracious.QualitativeSignal {
+	q := gracious.NewQualitativeSignal(name)
+	for x, value := range values {
+		q.Features[gracious.Address{X: x}] = value
+	}
+	return q
+}
+
+func TestComparison(t *testing.T) {
+	a := signalOf("a", map[int]int{0: 1, 1: 2, 2: 3})
+	b := signalOf("b", map[int]int{1: 2, 2: 1, 3: 4})
+	void := gracious.NewQualitativeSignal("void")
+	if overlap := a.Overlap(b); overlap != 2 {
+		t.Errorf("expected an overlap of 2, got %d", overlap)
+	}
+	if distance := a.HammingDistance(b); distance != 2 {
+		t.Errorf("expected a hamming distance of 2, got %d", distance)
+	}
+	if jaccard := a.Jaccard(b); jaccard != 0.5 {
+		t.Errorf("expected a jaccard index of 0.5, got %f", jaccard)
+	}
+	if dot := a.Dot(b); dot != 7 {
+		t.Errorf("expected a dot product of 7, got %d", dot)
+	}
+	if cosine := a.Cosine(b); math.Abs(cosine-7/math.Sqrt(14*21)) > 1e-9 {
+		t.Errorf("expected a cosine of %f, got %f", 7/math.Sqrt(14*21), cosine)
+	}
+	scaled := signalOf("scaled", map[int]int{0: 2, 1: 4, 2: 6})
+	if cosine := a.Cosine(scaled); math.Abs(cosine-1) > 1e-9 {
+		t.Errorf("expected proportional signals to have a cosine of 1, got %f", cosine)
+	}
+	if a.Jaccard(void) != 0 || void.Jaccard(void) != 0 || a.Cosine(void) != 0 {
+		t.Errorf("expected comparisons with an empty signal to score 0")
+	}
+}
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"testing"
+)
+
+// gridOf returns a signal with the features at the given Addresses.
+func gridOf(name string, values map[gracious.Address]int) gracious.QualitativeSignal {
+	q := gracious.NewQualitativeSignal(name)
+	for addr, value := range values {
+		q.Features[addr] = value
+	}
+	return q
+}
+
+// halving is a Competition that is not provided by gracious and so can not be persisted.
+type halving struct{}
+
+func (halving) Compete(q gracious.QualitativeSignal) gracious.QualitativeSignal {
+	return q.Clamp(0, 1)
+}
+
+func TestCompetition(t *testing.T) {
+	a := signalOf("a", map[int]int{0: 1, 1: 5, 2: 3, 3: 5, 4: 2})
+	original := a.Clone()
+	grid := gridOf("grid", map[gracious.Address]int{{X: 0}: 3, {X: 0, Y: 1}: 1, {X: 1}: 2, {X: 1, Y: 1}: 2, {X: 2, Y: 1}: 4})
+	for _, c := range []struct {
+		name        string
+		competition gracious.Competition
+		signal      gracious.QualitativeSignal
+		expected    gracious.QualitativeSignal
+	}{
+		{"MaxGap", gracious.MaxGap{Gap: 1}, a, signalOf("", map[int]int{1: 2, 3: 2})},
+		{"TopK", gracious.TopK{K: 3}, a, signalOf("", map[int]int{1: 5, 2: 3, 3: 5})},
+		{"TopKTies", gracious.TopK{K: 1}, a, signalOf("", map[int]int{1: 5, 3: 5})},
+		{"TopKNone", gracious.TopK{K: 0}, a, signalOf("", map[int]int{})},
+		{"Percentile", gracious.Percentile{Percent: 50}, a, signalOf("", map[int]int{1: 5, 2: 3, 3: 5})},
+		{"PercentileAll", gracious.Percentile{Percent: 0}, a, original},
+		{"SoftNormalize", gracious.SoftNormalize{Scale: 10}, a, signalOf("", map[int]int{0: 2, 1: 10, 2: 6, 3: 10, 4: 4})},
+		{"SoftNormalizeDrops", gracious.SoftNormalize{Scale: 1}, a, signalOf("", map[int]int{1: 1, 2: 1, 3: 1})},
+		{"PerColumn", gracious.PerColumn{}, grid,
+			gridOf("", map[gracious.Address]int{{X: 0}: 3, {X: 1}: 2, {X: 1, Y: 1}: 2, {X: 2, Y: 1}: 4})},
+		{"PerRow", gracious.PerRow{}, grid, gridOf("", map[gracious.Address]int{{X: 0}: 3, {X: 2, Y: 1}: 4})},
+	} {
+		actual := c.competition.Compete(c.signal)
+		if !sameFeatures(c.expected, actual) {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected.Represent(), actual.Represent())
+		}
+		if actual.Id != c.signal.Id {
+			t.Errorf("%s: expected the result to keep the id %q, got %q", c.name, c.signal.Id, actual.Id)
+		}
+	}
+	if !sameFeatures(original, a) {
+		t.Errorf("expected the competitions to leave the signal unchanged, got %s", a.Represent())
+	}
+}
+
+// TestGroupCompetition trains one group without competition and another with a Competition to recall words, and
+// checks that the Competition is applied to the firing pattern in place of WTA.
+func TestGroupCompetition(t *testing.T) {
+	colorJSA := io.JsonFromFileName("data/colorA.json")
+	wordJSA := io.JsonFromFileName("data/wordA.json")
+	open := gracious.NewBasicGroup("open")
+	open.WTA = -1
+	competing := gracious.NewBasicGroup("competing")
+	competing.WTA = 0
+	competing.Competition = gracious.PerColumn{}
+	for _, g := range []*gracious.BasicGroup{open, competing} {
+		train(g, wordJSA, colorJSA, 20)
+	}
+	for _, name := range colorNames {
+		association := colorJSA.GetJsonSignalById(name).ToDistributedSignal()
+		silence := gracious.NewQualitativeSignal("silence")
+		expected := gracious.PerColumn{}.Compete(open.Evoke(silence, association))
+		actual := competing.Evoke(silence, association)
+		if len(expected.Features) == 0 {
+			t.Errorf("%s: expected the word to be recalled", name)
+		}
+		if !sameFeatures(expected, actual) {
+			t.Errorf("%s: expected %s, got %s", name, expected.Represent(), actual.Represent())
+		}
+	}
+
+	var buffer bytes.Buffer
+	competing.Competition = gracious.TopK{K: 4}
+	if err := competing.Save(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := gracious.LoadBasicGroup(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Competition != (gracious.TopK{K: 4}) {
+		t.Errorf("expected the Competition to be restored, got %#v", restored.Competition)
+	}
+	competing.Competition = halving{}
+	if err := competing.Save(&buffer); !errors.Is(err, gracious.ErrUnsupportedCompetition) {
+		t.Errorf("expected ErrUnsupportedCompetition, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"bytes"
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"sync"
+	"testing"
+)
+
+// evokeConcurrently calls AsyncEvoke on the Group from many goroutines at once while other goroutines read and save
+// the state of the Group. Run with -race to detect unsynchronized access.
+func evokeConcurrently(t *testing.T, g gracious.Group, save func(buffer *bytes.Buffer) error, colors, words io.JsonSignalArray) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		for _, name := range colorNames {
+			color := colors.GetJsonSignalById(name).ToDistributedSignal()
+			word := words.GetJsonSignalById(name).ToDistributedSignal()
+			wg.Add(1)
+			go g.AsyncEvoke(color, word, &wg)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.GetFirePattern()
+			g.GetMatchPattern()
+			g.GetMisMatchPattern()
+			g.GetMatchLevel()
+			g.GetNoveltyPattern()
+			g.GetNoveltyLevel()
+			if err := save(new(bytes.Buffer)); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestBasicGroupConcurrentEvoke(t *testing.T) {
+	bg := gracious.NewBasicGroup("concurrentGroup")
+	bg.CorrelationThreshold = 5
+	save := func(buffer *bytes.Buffer) error { return bg.Save(buffer) }
+	evokeConcurrently(t, bg, save, io.JsonFromFileName("data/colorA.json"), io.JsonFromFileName("data/wordA.json"))
+}
+
+func TestAdvancedGroupConcurrentEvoke(t *testing.T) {
+	ag := gracious.NewAdvancedGroup("concurrentAdvancedGroup")
+	ag.CorrelationThreshold = 5
+	ag.GrdCorrelationThreshold = 3
+	save := func(buffer *bytes.Buffer) error { return ag.Save(buffer) }
+	evokeConcurrently(t, ag, save, io.JsonFromFileName("data/colorB.json"), io.JsonFromFileName("data/wordA.json"))
+}
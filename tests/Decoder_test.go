@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"testing"
+)
+
+func TestDecoder(t *testing.T) {
+	wordJSA := io.JsonFromFileName("data/wordA.json")
+	for _, metric := range []io.Metric{io.OverlapMetric, io.JaccardMetric, io.CosineMetric} {
+		decoder := io.NewDecoder(wordJSA, metric)
+		for _, word := range wordJSA.Signals {
+			decoding := decoder.Decode(word.ToDistributedSignal())
+			if metric == io.OverlapMetric {
+				// A longer word which holds every feature of a shorter word ties with it under overlap
+				if decoding.Labels[0].Score != float64(len(word.Features)) {
+					t.Errorf("expected %q to overlap itself completely, got %+v", word.Id, decoding)
+				}
+			} else if best, ok := decoding.Best(); !ok || best.Id != word.Id || best.Score < 0.999 {
+				t.Errorf("metric %d: expected %q, got %+v", metric, word.Id, decoding)
+			}
+			total := 0.0
+			for _, label := range decoding.Labels {
+				total += label.Confidence
+			}
+			if total < 0.999 || total > 1.001 {
+				t.Errorf("expected the confidences to sum to 1, got %f", total)
+			}
+		}
+	}
+	// One letter of red and one letter of blue overlap both words equally, but blue is the longer word
+	mixed := gracious.NewQualitativeSignal("mixed")
+	mixed.Features[gracious.Address{X: 0, Y: 18}] = 1
+	mixed.Features[gracious.Address{X: 0, Y: 2}] = 1
+	if decoding := io.NewDecoder(wordJSA, io.OverlapMetric).Decode(mixed); !decoding.Ambiguous {
+		t.Errorf("expected an ambiguous overlap decoding, got %+v", decoding)
+	}
+	if best, ok := io.NewDecoder(wordJSA, io.JaccardMetric).Decode(mixed).Best(); !ok || best.Id != "red" {
+		t.Errorf("expected red to be the best jaccard label, got %+v", best)
+	}
+	if _, ok := io.NewDecoder(wordJSA, io.CosineMetric).Decode(gracious.NewQualitativeSignal("void")).Best(); ok {
+		t.Errorf("expected an empty signal to have no best label")
+	}
+}
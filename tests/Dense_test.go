@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"testing"
+)
+
+func TestDenseSignalConversion(t *testing.T) {
+	wordJSA := io.JsonFromFileName("data/wordA.json")
+	for _, word := range wordJSA.ToDistributedSignals() {
+		origin, width, height := word.Bounds()
+		dense := word.Dense(origin, width, height)
+		if dense.Len() != len(word.Features) {
+			t.Errorf("%s: expected %d dense features, got %d", word.Id, len(word.Features), dense.Len())
+		}
+		if sparse := dense.ToQualitativeSignal(); !sameFeatures(word, sparse) {
+			t.Errorf("expected %s, got %s", word.Represent(), sparse.Represent())
+		}
+	}
+	dense := gracious.NewDenseSignal("bounded", gracious.Address{X: 1, Y: 1}, 2, 2)
+	if dense.Origin() != (gracious.Address{X: 1, Y: 1}) || dense.Width() != 2 || dense.Height() != 2 {
+		t.Errorf("expected the bounding box of the constructor, got %v %dx%d", dense.Origin(), dense.Width(), dense.Height())
+	}
+	if dense.Set(gracious.Address{X: 0, Y: 0}, 1) {
+		t.Errorf("expected a feature outside the bounding box to be rejected")
+	}
+	dense.Set(gracious.Address{X: 2, Y: 2}, 3)
+	dense.Set(gracious.Address{X: 2, Y: 2}, 0)
+	if dense.Len() != 0 {
+		t.Errorf("expected setting a feature to 0 to remove it, got %s", dense.Represent())
+	}
+}
+
+func TestEvokeDenseAgrees(t *testing.T) {
+	colorJSA := io.JsonFromFileName("data/colorB.json")
+	wordJSA := io.JsonFromFileName("data/wordA.json")
+	origin, width, height := gracious.Address{}, 16, 26
+	sparse := gracious.NewAdvancedGroup("sparse")
+	dense := gracious.NewAdvancedGroup("dense")
+	for _, ag := range []*gracious.AdvancedGroup{sparse, dense} {
+		ag.CorrelationThreshold = 5
+		ag.GrdCorrelationThreshold = 3
+	}
+	for _, name := range colorNames {
+		color := colorJSA.GetJsonSignalById(name).ToDistributedSignal()
+		word := wordJSA.GetJsonSignalById(name).ToDistributedSignal()
+		for i := 0; i < 12; i++ {
+			sparse.Evoke(color, word)
+			dense.EvokeDense(color, word.Dense(origin, width, height))
+		}
+	}
+	for _, name := range colorNames {
+		word := wordJSA.GetJsonSignalById(name).ToDistributedSignal()
+		expected := sparse.Evoke(gracious.NewQualitativeSignal("void"), word)
+		actual := dense.EvokeDense(gracious.NewQualitativeSignal("void"), word.Dense(origin, width, height))
+		if !sameFeatures(expected, actual) {
+			t.Errorf("%s: expected %s, got %s", name, expected.Represent(), actual.Represent())
+		}
+	}
+}
+
+// imageAssociation returns a 64x64 image-like association signal with roughly a third of its features active.
+func imageAssociation() gracious.QualitativeSignal {
+	association := gracious.NewQualitativeSignal("image")
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 64; y++ {
+			if (x*7+y*13)%3 == 0 {
+				association.Features[gracious.Address{X: x, Y: y}] = 1
+			}
+		}
+	}
+	return association
+}
+
+func BenchmarkEvokeSparse(b *testing.B) {
+	bg, _ := sparseGroup(256, gracious.EvaluateSequential)
+	association := imageAssociation()
+	void := gracious.NewQualitativeSignal("void")
+	bg.Evoke(void, association)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bg.Evoke(void, association)
+	}
+}
+
+func BenchmarkEvokeDense(b *testing.B) {
+	bg, _ := sparseGroup(256, gracious.EvaluateSequential)
+	sparse := imageAssociation()
+	association := sparse.Dense(gracious.Address{}, 64, 64)
+	void := gracious.NewQualitativeSignal("void")
+	bg.EvokeDense(void, association)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bg.EvokeDense(void, association)
+	}
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"fmt"
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"testing"
+)
+
+var evaluations = []struct {
+	name       string
+	evaluation gracious.Evaluation
+}{
+	{"PerNeuron", gracious.EvaluatePerNeuron},
+	{"Sequential", gracious.EvaluateSequential},
+	{"WorkerPool", gracious.EvaluateWorkerPool},
+	{"Chunked", gracious.EvaluateChunked},
+}
+
+func TestEvaluationStrategiesAgree(t *testing.T) {
+	colorJSA := io.JsonFromFileName("data/colorB.json")
+	wordJSA := io.JsonFromFileName("data/wordA.json")
+	recalls := make(map[string][]gracious.QualitativeSignal)
+	for _, e := range evaluations {
+		ag := gracious.NewAdvancedGroup("evaluation" + e.name)
+		ag.CorrelationThreshold = 5
+		ag.GrdCorrelationThreshold = 3
+		ag.Evaluation = e.evaluation
+		ag.Workers = 3
+		train(ag, colorJSA, wordJSA, 12)
+		for _, name := range colorNames {
+			word := wordJSA.GetJsonSignalById(name).ToDistributedSignal()
+			recalls[e.name] = append(recalls[e.name], ag.Evoke(gracious.NewQualitativeSignal("void"), word))
+		}
+	}
+	for _, e := range evaluations[1:] {
+		for i, recall := range recalls[e.name] {
+			if expected := recalls[evaluations[0].name][i]; !sameFeatures(expected, recall) {
+				t.Errorf("%s recalled %s, expected %s", e.name, recall.Represent(), expected.Represent())
+			}
+		}
+	}
+}
+
+// sparseGroup returns a BasicGroup with the given number of neurons laid out on a square grid, each of which has
+// learnt synapses for a handful of association features, along with the association signal.
+func sparseGroup(neurons int, evaluation gracious.Evaluation) (*gracious.BasicGroup, gracious.QualitativeSignal) {
+	bg := gracious.NewBasicGroup("benchmark")
+	bg.CorrelationThreshold = 1
+	bg.Evaluation = evaluation
+	main := gracious.NewQualitativeSignal("main")
+	for i := 0; i < neurons; i++ {
+		main.Features[gracious.Address{X: i % 128, Y: i / 128}] = 1
+	}
+	association := gracious.NewQualitativeSignal("association")
+	for i := 0; i < 8; i++ {
+		association.Features[gracious.Address{X: i}] = 1
+	}
+	for i := 0; i < 3; i++ {
+		bg.Evoke(main, association)
+	}
+	return bg, association
+}
+
+// BenchmarkEvaluation compares the Evaluation strategies over a range of group sizes. Run with -bench Evaluation to
+// find the group size at which the parallel strategies overtake EvaluateSequential on a given machine.
+func BenchmarkEvaluation(b *testing.B) {
+	for _, neurons := range []int{16, 256, 4096, 32768} {
+		for _, e := range evaluations {
+			b.Run(fmt.Sprint(e.name, "/", neurons), func(b *testing.B) {
+				bg, association := sparseGroup(neurons, e.evaluation)
+				void := gracious.NewQualitativeSignal("void")
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					bg.Evoke(void, association)
+				}
+			})
+		}
+	}
+}
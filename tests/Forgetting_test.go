@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"github.com/Art-of-the-Living/gracious"
+	"testing"
+)
+
+func TestSynapseForgetting(t *testing.T) {
+	syn := gracious.NewSynapse()
+	syn.Train(1, 1, 0)
+	syn.Train(1, 1, 0)
+	if syn.Evoke(1) != gracious.ExcitatoryWeight {
+		t.Fatalf("expected the synapse to be excitatory, got %d", syn.Evoke(1))
+	}
+	syn.Decay(2, 0)
+	if syn.Evoke(1) != gracious.ExcitatoryWeight {
+		t.Errorf("expected the synapse to stay excitatory while its correlation is above the threshold")
+	}
+	syn.Decay(2, 0)
+	if syn.Evoke(1) != gracious.NeutralWeight {
+		t.Errorf("expected the decayed synapse to be neutral, got %d", syn.Evoke(1))
+	}
+	syn.Train(1, 1, 0)
+	syn.Train(1, 1, 0)
+	syn.Unlearn(-1, 1, 0)
+	syn.Unlearn(-1, 1, 0)
+	if syn.Evoke(1) != gracious.NeutralWeight {
+		t.Errorf("expected the unlearnt synapse to be neutral, got %d", syn.Evoke(1))
+	}
+	syn.Train(1, 1, 0)
+	syn.Train(1, 1, 0)
+	syn.Forget()
+	if syn.Evoke(1) != gracious.NeutralWeight {
+		t.Errorf("expected the forgotten synapse to be neutral, got %d", syn.Evoke(1))
+	}
+	syn.Train(1, 1, 0)
+	if syn.Evoke(1) != gracious.NeutralWeight {
+		t.Errorf("expected the forgotten synapse to have lost its correlation")
+	}
+}
+
+// TestUnlearnOnMismatch teaches a group that a feature means go, and then that it means stop instead.
+func TestUnlearnOnMismatch(t *testing.T) {
+	feature := signalOf("feature", map[int]int{5: 1})
+	silence := gracious.NewQualitativeSignal("silence")
+	for _, unlearn := range []bool{false, true} {
+		g := gracious.NewBasicGroup("traffic")
+		g.WTA = -1
+		g.MismatchInhibition = true
+		g.UnlearnOnMismatch = unlearn
+		for i := 0; i < 5; i++ {
+			g.Evoke(goSignal, feature)
+		}
+		for i := 0; i < 5; i++ {
+			g.Evoke(stopSignal, feature)
+		}
+		actual := g.Evoke(silence, feature)
+		if unlearn && actual.HammingDistance(stopSignal) != 0 {
+			t.Errorf("expected the feature to evoke stop alone once go is unlearnt, got %s", actual.Represent())
+		}
+		if !unlearn && actual.Overlap(goSignal) == 0 {
+			t.Errorf("expected the feature to still evoke go without unlearning, got %s", actual.Represent())
+		}
+	}
+}
+
+// TestCorrelationDecay checks that an association which is no longer used is forgotten while one in use is kept.
+func TestCorrelationDecay(t *testing.T) {
+	a, b := signalOf("a", map[int]int{5: 1}), signalOf("b", map[int]int{6: 1})
+	g := gracious.NewBasicGroup("decaying")
+	g.WTA = -1
+	g.CorrelationDecay = 1
+	for i := 0; i < 2; i++ {
+		g.Evoke(goSignal, a)
+		g.Evoke(stopSignal, b)
+	}
+	silence := gracious.NewQualitativeSignal("silence")
+	if actual := g.Evoke(silence, a); actual.HammingDistance(goSignal) != 0 {
+		t.Fatalf("expected a to evoke go, got %s", actual.Represent())
+	}
+	for i := 0; i < 3; i++ {
+		g.Evoke(stopSignal, b)
+	}
+	if actual := g.Evoke(silence, a); len(actual.Features) != 0 {
+		t.Errorf("expected the unused association of a to be forgotten, got %s", actual.Represent())
+	}
+	if actual := g.Evoke(silence, b); actual.HammingDistance(stopSignal) != 0 {
+		t.Errorf("expected b to still evoke stop, got %s", actual.Represent())
+	}
+}
+
+func TestForget(t *testing.T) {
+	a, b := signalOf("a", map[int]int{5: 1}), signalOf("b", map[int]int{6: 1})
+	g := gracious.NewBasicGroup("forgetful")
+	g.WTA = -1
+	for i := 0; i < 3; i++ {
+		g.Evoke(goSignal, a)
+		g.Evoke(stopSignal, b)
+	}
+	silence := gracious.NewQualitativeSignal("silence")
+	g.Forget(gracious.Address{X: 0})
+	if actual := g.Evoke(silence, a); len(actual.Features) != 0 {
+		t.Errorf("expected the forgotten neuron not to fire, got %s", actual.Represent())
+	}
+	if actual := g.Evoke(silence, b); actual.HammingDistance(stopSignal) != 0 {
+		t.Errorf("expected the other neuron to be kept, got %s", actual.Represent())
+	}
+	dense := b.Dense(gracious.Address{X: 5}, 2, 1)
+	if actual := g.EvokeDense(silence, dense); actual.HammingDistance(stopSignal) != 0 {
+		t.Errorf("expected the dense association to evoke stop, got %s", actual.Represent())
+	}
+	g.ForgetAssociation(gracious.Address{X: 6})
+	if actual := g.EvokeDense(silence, dense); len(actual.Features) != 0 {
+		t.Errorf("expected the forgotten association not to evoke anything, got %s", actual.Represent())
+	}
+}
+
+// TestForgetAdvancedAssociation checks that forgetting the features of an association makes the grandmother set of an
+// AdvancedGroup forget the pattern they made up.
+func TestForgetAdvancedAssociation(t *testing.T) {
+	pattern := signalOf("pattern", map[int]int{40: 1, 41: 1})
+	g := gracious.NewAdvancedGroup("forgetful")
+	g.WTA = -1
+	for i := 0; i < 10; i++ {
+		g.Evoke(goSignal, pattern)
+	}
+	silence := gracious.NewQualitativeSignal("silence")
+	if actual := g.Evoke(silence, pattern); actual.HammingDistance(goSignal) != 0 {
+		t.Fatalf("expected the pattern to evoke go, got %s", actual.Represent())
+	}
+	g.ForgetAssociation(gracious.Address{X: 40})
+	g.ForgetAssociation(gracious.Address{X: 41})
+	if actual := g.Evoke(silence, pattern); len(actual.Features) != 0 {
+		t.Errorf("expected the forgotten pattern not to evoke anything, got %s", actual.Represent())
+	}
+}
@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"testing"
+)
+
+// TestFreeze checks that a frozen group recalls what it has learnt without learning anything more.
+func TestFreeze(t *testing.T) {
+	a, b := signalOf("a", map[int]int{5: 1}), signalOf("b", map[int]int{6: 1})
+	silence := gracious.NewQualitativeSignal("silence")
+	g := gracious.NewBasicGroup("frozen")
+	g.WTA = -1
+	for i := 0; i < 3; i++ {
+		g.Evoke(goSignal, a)
+	}
+	g.Freeze()
+	for i := 0; i < 3; i++ {
+		g.Evoke(stopSignal, b)
+		g.EvokeDense(stopSignal, b.Dense(gracious.Address{X: 5}, 2, 1))
+	}
+	if actual := g.Evoke(silence, a); actual.HammingDistance(goSignal) != 0 {
+		t.Errorf("expected the frozen group to recall go, got %s", actual.Represent())
+	}
+	if actual := g.Evoke(silence, b); len(actual.Features) != 0 {
+		t.Errorf("expected the frozen group not to learn b, got %s", actual.Represent())
+	}
+	if level := g.GetMatchLevel(); level != 1 {
+		t.Errorf("expected the frozen group not to grow the stop neuron, got a match level of %d", level)
+	}
+	var buffer bytes.Buffer
+	if err := g.Save(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := gracious.LoadBasicGroup(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !restored.Frozen() {
+		t.Errorf("expected the restored group to be frozen")
+	}
+	restored.Unfreeze()
+	for i := 0; i < 3; i++ {
+		restored.Evoke(stopSignal, b)
+	}
+	if actual := restored.Evoke(silence, b); actual.HammingDistance(stopSignal) != 0 {
+		t.Errorf("expected the unfrozen group to learn b, got %s", actual.Represent())
+	}
+}
+
+// TestLearningMask limits learning to the go neuron, so that only the association of go is learnt.
+func TestLearningMask(t *testing.T) {
+	a, b := signalOf("a", map[int]int{5: 1}), signalOf("b", map[int]int{6: 1})
+	silence := gracious.NewQualitativeSignal("silence")
+	g := gracious.NewBasicGroup("masked")
+	g.WTA = -1
+	g.SetLearningMask(goSignal)
+	var buffer bytes.Buffer
+	if err := g.Save(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := gracious.LoadBasicGroup(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, group := range []*gracious.BasicGroup{g, restored} {
+		for i := 0; i < 3; i++ {
+			group.Evoke(goSignal, a)
+			group.Evoke(stopSignal, b)
+		}
+		if actual := group.Evoke(silence, a); actual.HammingDistance(goSignal) != 0 {
+			t.Errorf("expected the masked group to learn a, got %s", actual.Represent())
+		}
+		if actual := group.Evoke(silence, b); len(actual.Features) != 0 {
+			t.Errorf("expected the masked group not to learn b, got %s", actual.Represent())
+		}
+	}
+	g.ClearLearningMask()
+	for i := 0; i < 3; i++ {
+		g.Evoke(stopSignal, b)
+	}
+	if actual := g.Evoke(silence, b); actual.HammingDistance(stopSignal) != 0 {
+		t.Errorf("expected the group to learn b once the mask is cleared, got %s", actual.Represent())
+	}
+}
+
+// TestEvokeGated checks that every kind of group only learns while its gate is open.
+func TestEvokeGated(t *testing.T) {
+	closed := gracious.NewQualitativeSignal("closed")
+	open := signalOf("open", map[int]int{0: 1})
+	silence := gracious.NewQualitativeSignal("silence")
+	for _, g := range []gracious.GatedGroup{
+		gracious.NewBasicGroup("basic"),
+		gracious.NewAdvancedGroup("advanced"),
+		gracious.NewSequenceGroup("sequence"),
+	} {
+		for i := 0; i < 5; i++ {
+			g.EvokeGated(goSignal, greenShape, closed)
+		}
+		if actual := g.EvokeGated(silence, greenShape, closed); len(actual.Features) != 0 {
+			t.Errorf("%s: expected nothing to be learnt with the gate closed, got %s", g.GetId(), actual.Represent())
+		}
+		for i := 0; i < 10; i++ {
+			g.EvokeGated(goSignal, greenShape, open)
+		}
+		if actual := g.EvokeGated(silence, greenShape, closed); actual.HammingDistance(goSignal) != 0 {
+			t.Errorf("%s: expected go to be learnt with the gate open, got %s", g.GetId(), actual.Represent())
+		}
+	}
+}
+
+func TestNetworkGatePort(t *testing.T) {
+	a := signalOf("a", map[int]int{5: 1})
+	gateOpen := false
+	main := io.NewFunctionalSensor("main", func() gracious.QualitativeSignal { return goSignal })
+	association := io.NewFunctionalSensor("association", func() gracious.QualitativeSignal { return a })
+	gate := io.NewFunctionalSensor("gate", func() gracious.QualitativeSignal {
+		if gateOpen {
+			return signalOf("gate", map[int]int{0: 1})
+		}
+		return gracious.NewQualitativeSignal("gate")
+	})
+	g := gracious.NewBasicGroup("gated")
+	g.WTA = -1
+	network := gracious.NewNetwork("network")
+	for _, err := range []error{
+		network.AddGroup(g),
+		network.AddSource(main),
+		network.AddSource(association),
+		network.AddSource(gate),
+		network.Connect("main", "gated", gracious.MainPort),
+		network.Connect("association", "gated", gracious.AssociationPort),
+		network.Connect("gate", "gated", gracious.GatePort),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := network.Connect("gated", "main", gracious.GatePort); !errors.Is(err, gracious.ErrNoGatePort) {
+		t.Errorf("expected ErrNoGatePort, got %v", err)
+	}
+	silence := gracious.NewQualitativeSignal("silence")
+	for i := 0; i < 3; i++ {
+		network.Step()
+	}
+	g.Freeze()
+	if actual := g.Evoke(silence, a); len(actual.Features) != 0 {
+		t.Errorf("expected nothing to be learnt with the gate closed, got %s", actual.Represent())
+	}
+	g.Unfreeze()
+	gateOpen = true
+	for i := 0; i < 3; i++ {
+		network.Step()
+	}
+	g.Freeze()
+	if actual := g.Evoke(silence, a); actual.HammingDistance(goSignal) != 0 {
+		t.Errorf("expected go to be learnt with the gate open, got %s", actual.Represent())
+	}
+}
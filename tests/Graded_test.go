@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"bytes"
+	"github.com/Art-of-the-Living/gracious"
+	"testing"
+)
+
+// TestGradedWeights pairs one feature with go far more often than another, and checks that only a graded group
+// recalls go more strongly for the frequent pairing.
+func TestGradedWeights(t *testing.T) {
+	frequent, rare := signalOf("frequent", map[int]int{5: 1}), signalOf("rare", map[int]int{6: 1})
+	silence := gracious.NewQualitativeSignal("silence")
+	goAddress := gracious.Address{X: 0}
+	for _, cap := range []int{0, 3} {
+		g := gracious.NewBasicGroup("graded")
+		g.WTA = -1
+		g.WeightCap = cap
+		for i := 0; i < 10; i++ {
+			g.Evoke(goSignal, frequent)
+		}
+		for i := 0; i < 2; i++ {
+			g.Evoke(goSignal, rare)
+		}
+		var buffer bytes.Buffer
+		if err := g.Save(&buffer); err != nil {
+			t.Fatal(err)
+		}
+		restored, err := gracious.LoadBasicGroup(&buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		strong := restored.Evoke(silence, frequent).Features[goAddress]
+		weak := restored.Evoke(silence, rare).Features[goAddress]
+		if weak != 1 {
+			t.Errorf("cap %d: expected the rare pairing to recall go with a strength of 1, got %d", cap, weak)
+		}
+		if cap == 0 && strong != 1 {
+			t.Errorf("cap %d: expected bipolar weights to recall go with a strength of 1, got %d", cap, strong)
+		}
+		if cap > 0 && strong != cap {
+			t.Errorf("cap %d: expected the frequent pairing to recall go with the capped strength, got %d", cap, strong)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"bytes"
+	"github.com/Art-of-the-Living/gracious"
+	"testing"
+)
+
+func TestLateralInhibition(t *testing.T) {
+	row := signalOf("row", map[int]int{0: 2, 1: 6, 2: 2, 3: 2, 4: 2})
+	column := gridOf("column", map[gracious.Address]int{{X: 0}: 4, {X: 0, Y: 1}: 1, {X: 3, Y: 3}: 1})
+	edge := gracious.LateralInhibition{Kernel: map[gracious.Address]int{{X: 1}: 100}}
+	for _, c := range []struct {
+		name       string
+		inhibition gracious.LateralInhibition
+		signal     gracious.QualitativeSignal
+		expected   gracious.QualitativeSignal
+	}{
+		{"Row", gracious.LateralInhibition{Radius: 1, Strength: 50}, row, signalOf("", map[int]int{1: 4, 4: 1})},
+		{"Column", gracious.LateralInhibition{Radius: 1, Strength: 100}, column,
+			gridOf("", map[gracious.Address]int{{X: 0}: 3, {X: 3, Y: 3}: 1})},
+		{"NoRadius", gracious.LateralInhibition{Strength: 100}, row, row},
+		{"Kernel", edge, signalOf("", map[int]int{0: 3, 1: 3, 2: 3, 5: 1}), signalOf("", map[int]int{0: 3, 5: 1})},
+	} {
+		actual := c.inhibition.Compete(c.signal)
+		if !sameFeatures(c.expected, actual) {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected.Represent(), actual.Represent())
+		}
+	}
+
+	// A group passing its main signal through sharpens it before it is output
+	g := gracious.NewBasicGroup("retina")
+	g.PassThrough = true
+	g.Competition = edge
+	expected := edge.Compete(row)
+	if actual := g.Evoke(row, gracious.NewQualitativeSignal("void")); !sameFeatures(expected, actual) {
+		t.Errorf("expected the group to output %s, got %s", expected.Represent(), actual.Represent())
+	}
+	var buffer bytes.Buffer
+	if err := g.Save(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := gracious.LoadBasicGroup(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := restored.Competition.Compete(row); !sameFeatures(expected, actual) {
+		t.Errorf("expected the restored kernel to output %s, got %s", expected.Represent(), actual.Represent())
+	}
+}
+
+// TestEmptyKernelPersistence checks that an empty Kernel, which inhibits nothing, still inhibits nothing once the group
+// is restored, rather than falling back to the Radius and Strength.
+func TestEmptyKernelPersistence(t *testing.T) {
+	g := gracious.NewBasicGroup("retina")
+	g.PassThrough = true
+	g.Competition = gracious.LateralInhibition{Radius: 1, Strength: 50, Kernel: map[gracious.Address]int{}}
+	main := signalOf("main", map[int]int{0: 1, 1: 1, 2: 1})
+	void := gracious.NewQualitativeSignal("void")
+	expected := g.Evoke(main, void)
+	if !sameFeatures(main, expected) {
+		t.Fatalf("expected the empty kernel to inhibit nothing, got %s", expected.Represent())
+	}
+	var buffer bytes.Buffer
+	if err := g.Save(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := gracious.LoadBasicGroup(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := restored.Evoke(main, void); !sameFeatures(expected, actual) {
+		t.Errorf("expected the restored group to output %s, got %s", expected.Represent(), actual.Represent())
+	}
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"bytes"
+	"github.com/Art-of-the-Living/gracious"
+	"testing"
+)
+
+// The association features of a shape, S1 and S2, in green, G, or red, R, and the neurons for go and stop.
+var (
+	greenShape = signalOf("greenShape", map[int]int{0: 1, 1: 1, 2: 1})
+	redShape   = signalOf("redShape", map[int]int{0: 1, 1: 1, 3: 1})
+	goSignal   = signalOf("go", map[int]int{0: 1})
+	stopSignal = signalOf("stop", map[int]int{1: 1})
+)
+
+// TestInhibitoryTraining teaches a group that a green shape means go, and then with negative training that the same
+// shape in red does not.
+func TestInhibitoryTraining(t *testing.T) {
+	g := gracious.NewBasicGroup("traffic")
+	g.WTA = -1
+	silence := gracious.NewQualitativeSignal("silence")
+	for i := 0; i < 5; i++ {
+		g.Evoke(goSignal, greenShape)
+	}
+	if actual := g.Evoke(silence, redShape); actual.HammingDistance(goSignal) != 0 {
+		t.Fatalf("expected the shared shape to evoke go before inhibition is learnt, got %s", actual.Represent())
+	}
+	notGo := signalOf("notGo", map[int]int{0: -1})
+	for i := 0; i < 5; i++ {
+		g.Evoke(notGo, redShape)
+	}
+	if actual := g.Evoke(silence, redShape); len(actual.Features) != 0 {
+		t.Errorf("expected red to inhibit go, got %s", actual.Represent())
+	}
+	if actual := g.Evoke(silence, greenShape); actual.HammingDistance(goSignal) != 0 {
+		t.Errorf("expected green to still evoke go, got %s", actual.Represent())
+	}
+
+	passing := gracious.NewBasicGroup("passing")
+	passing.PassThrough = true
+	if actual := passing.Evoke(notGo, redShape); len(actual.Features) != 0 {
+		t.Errorf("expected negative training not to pass through, got %s", actual.Represent())
+	}
+}
+
+// TestInhibitionIsKept checks that a learnt exclusion survives a later positive example of the excluded feature.
+func TestInhibitionIsKept(t *testing.T) {
+	g := gracious.NewBasicGroup("traffic")
+	g.WTA = -1
+	silence := gracious.NewQualitativeSignal("silence")
+	notGo := signalOf("notGo", map[int]int{0: -1})
+	for i := 0; i < 5; i++ {
+		g.Evoke(goSignal, greenShape)
+	}
+	for i := 0; i < 5; i++ {
+		g.Evoke(notGo, redShape)
+	}
+	g.Evoke(goSignal, redShape)
+	if actual := g.Evoke(silence, redShape); len(actual.Features) != 0 {
+		t.Errorf("expected red to still inhibit go after one positive example, got %s", actual.Represent())
+	}
+}
+
+// TestMismatchInhibition teaches go and stop for the green and red shape, and relies on MismatchInhibition to make
+// each neuron inhibit the color of the other.
+func TestMismatchInhibition(t *testing.T) {
+	g := gracious.NewBasicGroup("traffic")
+	g.WTA = -1
+	g.MismatchInhibition = true
+	for i := 0; i < 5; i++ {
+		g.Evoke(goSignal, greenShape)
+		g.Evoke(stopSignal, redShape)
+	}
+	var buffer bytes.Buffer
+	if err := g.Save(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := gracious.LoadBasicGroup(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	silence := gracious.NewQualitativeSignal("silence")
+	for _, group := range []*gracious.BasicGroup{g, restored} {
+		if actual := group.Evoke(silence, greenShape); actual.HammingDistance(goSignal) != 0 {
+			t.Errorf("%s: expected green to evoke go alone, got %s", group.GetId(), actual.Represent())
+		}
+		if actual := group.Evoke(silence, redShape); actual.HammingDistance(stopSignal) != 0 {
+			t.Errorf("%s: expected red to evoke stop alone, got %s", group.GetId(), actual.Represent())
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"strings"
+	"testing"
+)
+
+func TestLayeredAddress(t *testing.T) {
+	if r := (gracious.Address{X: 1, Y: 2}).Represent(); r != "@(1,2)" {
+		t.Errorf("expected a 2D Address to be represented as @(1,2), got %s", r)
+	}
+	if r := (gracious.Address{X: 1, Y: 2, Z: 3}).Represent(); r != "@(1,2,3)" {
+		t.Errorf("expected a layered Address to be represented as @(1,2,3), got %s", r)
+	}
+
+	// Features at the same X,Y on different layers are distinct
+	layered := gridOf("layered", map[gracious.Address]int{{X: 1, Y: 1}: 2, {X: 1, Y: 1, Z: 1}: 3})
+	moved := layered.Translate(gracious.Address{Z: 1})
+	expected := gridOf("", map[gracious.Address]int{{X: 1, Y: 1, Z: 1}: 2, {X: 1, Y: 1, Z: 2}: 3})
+	if !sameFeatures(expected, moved) {
+		t.Errorf("expected %s, got %s", expected.Represent(), moved.Represent())
+	}
+	cropped := layered.Crop(gracious.Address{Z: 1}, 4, 4)
+	if expected := gridOf("", map[gracious.Address]int{{X: 1, Y: 1, Z: 1}: 3}); !sameFeatures(expected, cropped) {
+		t.Errorf("expected %s, got %s", expected.Represent(), cropped.Represent())
+	}
+	dense := layered.Dense(gracious.Address{}, 4, 4)
+	if dense.Len() != 1 || dense.Get(gracious.Address{X: 1, Y: 1}) != 2 {
+		t.Errorf("expected the DenseSignal to hold layer 0 only, got %s", dense.Represent())
+	}
+
+	// Json translation keeps the layer, and leaves it out for layer 0
+	js := io.JsonFromDistributedSignal(layered)
+	if restored := js.ToDistributedSignal(); !sameFeatures(layered, restored) {
+		t.Errorf("expected %s, got %s", layered.Represent(), restored.Represent())
+	}
+	flat, err := json.Marshal(io.JsonFromDistributedSignal(signalOf("flat", map[int]int{0: 1})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(flat), "Z") {
+		t.Errorf("expected a 2D signal to be encoded without a layer, got %s", flat)
+	}
+}
+
+// TestLayeredGroup associates signals that only differ by layer and checks that a saved group keeps them apart.
+func TestLayeredGroup(t *testing.T) {
+	g := gracious.NewBasicGroup("layers")
+	g.WTA = -1
+	top := gridOf("top", map[gracious.Address]int{{X: 0}: 1})
+	bottom := gridOf("bottom", map[gracious.Address]int{{X: 1}: 1})
+	front := gridOf("front", map[gracious.Address]int{{X: 2, Y: 2}: 1})
+	back := gridOf("back", map[gracious.Address]int{{X: 2, Y: 2, Z: 1}: 1})
+	for i := 0; i < 5; i++ {
+		g.Evoke(top, front)
+		g.Evoke(bottom, back)
+	}
+	var buffer bytes.Buffer
+	if err := g.Save(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := gracious.LoadBasicGroup(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	silence := gracious.NewQualitativeSignal("silence")
+	for _, c := range []struct{ association, expected gracious.QualitativeSignal }{{front, top}, {back, bottom}} {
+		if actual := restored.Evoke(silence, c.association); actual.HammingDistance(c.expected) != 0 {
+			t.Errorf("expected %s to evoke %s, got %s", c.association.Id, c.expected.Represent(), actual.Represent())
+		}
+	}
+
+	s := gracious.NewSequenceGroup("sequence")
+	if s.TraceOffset != (gracious.Address{Z: 1}) {
+		t.Errorf("expected the trace to be placed on layer 1, got %s", s.TraceOffset.Represent())
+	}
+}
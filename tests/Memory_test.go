@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"testing"
+)
+
+func TestShortTermMemory(t *testing.T) {
+	colorJSA := io.JsonFromFileName("data/colorA.json")
+	red := colorJSA.GetJsonSignalById("red").ToDistributedSignal()
+	green := colorJSA.GetJsonSignalById("green").ToDistributedSignal()
+	blue := colorJSA.GetJsonSignalById("blue").ToDistributedSignal()
+	void := gracious.NewQualitativeSignal("void")
+	stm := gracious.NewShortTermMemory("stm", 2, 4)
+	stm.Evoke(red)
+	content := stm.Evoke(green)
+	if content.Features[gracious.Address{X: 0, Y: 0}] != 3 {
+		t.Errorf("expected red to be sustained at strength 3, got %s", content.Represent())
+	}
+	if content = stm.Evoke(blue); stm.Len() != 2 || content.Features[gracious.Address{X: 0, Y: 0}] != 0 {
+		t.Errorf("expected red to be forgotten to make room for blue, got %s", content.Represent())
+	}
+	stm.Evoke(void)
+	stm.Attend(green)
+	for i := 0; i < 3; i++ {
+		content = stm.Evoke(void)
+	}
+	if content.Features[gracious.Address{X: 0, Y: 1}] == 0 || content.Features[gracious.Address{X: 0, Y: 2}] != 0 {
+		t.Errorf("expected only the attended green to be sustained, got %s", content.Represent())
+	}
+	if content = stm.Evoke(void); stm.Len() != 0 {
+		t.Errorf("expected every percept to have decayed, got %s", content.Represent())
+	}
+}
+
+func TestShortTermMemoryAsAssociation(t *testing.T) {
+	colorJSA := io.JsonFromFileName("data/colorA.json")
+	wordJSA := io.JsonFromFileName("data/wordA.json")
+	bg := gracious.NewBasicGroup("stmGroup")
+	bg.CorrelationThreshold = 5
+	stm := gracious.NewShortTermMemory("stm", 1, 4)
+	red := colorJSA.GetJsonSignalById("red").ToDistributedSignal()
+	stm.Evoke(wordJSA.GetJsonSignalById("red").ToDistributedSignal())
+	for i := 0; i < 3; i++ {
+		bg.Evoke(red, stm.Evoke(gracious.NewQualitativeSignal("void")))
+	}
+	stm.Evoke(wordJSA.GetJsonSignalById("red").ToDistributedSignal())
+	evocation := bg.Evoke(gracious.NewQualitativeSignal("void"), stm.Evoke(gracious.NewQualitativeSignal("void")))
+	if evocation.Features[gracious.Address{X: 0, Y: 0}] == 0 {
+		t.Errorf("expected the sustained word to evoke red, got %s", evocation.Represent())
+	}
+}
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"errors"
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"testing"
+)
+
+var (
+	neutral  = gracious.NewQualitativeSignal("neutral")
+	pleasure = signalOf("pleasure", map[int]int{0: 1})
+	pain     = signalOf("pain", map[int]int{0: -1})
+)
+
+// TestModulatedLearning checks that nothing is learnt without an outcome, and that stronger pleasure learns faster.
+func TestModulatedLearning(t *testing.T) {
+	a := signalOf("a", map[int]int{5: 1})
+	intense := signalOf("intense", map[int]int{0: 2, 1: 1})
+	for _, modulation := range []gracious.QualitativeSignal{neutral, pleasure, intense} {
+		g := gracious.NewBasicGroup("modulated")
+		g.WTA = -1
+		g.CorrelationThreshold = 5
+		for i := 0; i < 2; i++ {
+			g.EvokeModulated(goSignal, a, modulation)
+		}
+		actual := g.EvokeModulated(neutral, a, neutral)
+		if modulation.Id == intense.Id && actual.HammingDistance(goSignal) != 0 {
+			t.Errorf("expected intense pleasure to learn go quickly, got %s", actual.Represent())
+		}
+		if modulation.Id != intense.Id && len(actual.Features) != 0 {
+			t.Errorf("%s: expected go not to be learnt yet, got %s", modulation.Id, actual.Represent())
+		}
+	}
+}
+
+// TestPainfulLearning teaches a group that going for a green shape is pleasant, and then that going for the same
+// shape in red is painful.
+func TestPainfulLearning(t *testing.T) {
+	g := gracious.NewBasicGroup("traffic")
+	g.WTA = -1
+	for i := 0; i < 5; i++ {
+		g.EvokeModulated(goSignal, greenShape, pleasure)
+	}
+	for i := 0; i < 5; i++ {
+		g.EvokeModulated(goSignal, redShape, pain)
+	}
+	if actual := g.Evoke(neutral, redShape); len(actual.Features) != 0 {
+		t.Errorf("expected pain to inhibit go for red, got %s", actual.Represent())
+	}
+	if actual := g.Evoke(neutral, greenShape); actual.HammingDistance(goSignal) != 0 {
+		t.Errorf("expected green to still evoke go, got %s", actual.Represent())
+	}
+
+	a := signalOf("a", map[int]int{5: 1})
+	unlearning := gracious.NewBasicGroup("unlearning")
+	unlearning.WTA = -1
+	unlearning.UnlearnOnMismatch = true
+	for i := 0; i < 5; i++ {
+		unlearning.EvokeModulated(goSignal, a, pleasure)
+	}
+	for i := 0; i < 5; i++ {
+		unlearning.EvokeModulated(goSignal, a, pain)
+	}
+	if actual := unlearning.Evoke(neutral, a); len(actual.Features) != 0 {
+		t.Errorf("expected pain to unlearn go for a, got %s", actual.Represent())
+	}
+}
+
+// TestNetworkModulationPort wires a reward sensor into a group, which only learns the pairings that are rewarded.
+func TestNetworkModulationPort(t *testing.T) {
+	a, b := signalOf("a", map[int]int{5: 1}), signalOf("b", map[int]int{6: 1})
+	association := a
+	main := io.NewFunctionalSensor("main", func() gracious.QualitativeSignal { return goSignal })
+	stimulus := io.NewFunctionalSensor("stimulus", func() gracious.QualitativeSignal { return association })
+	reward := io.NewFunctionalSensor("reward", func() gracious.QualitativeSignal {
+		if association.Id == a.Id {
+			return pleasure
+		}
+		return neutral
+	})
+	g := gracious.NewBasicGroup("rewarded")
+	g.WTA = -1
+	network := gracious.NewNetwork("network")
+	for _, err := range []error{
+		network.AddGroup(g),
+		network.AddSource(main),
+		network.AddSource(stimulus),
+		network.AddSource(reward),
+		network.Connect("main", "rewarded", gracious.MainPort),
+		network.Connect("stimulus", "rewarded", gracious.AssociationPort),
+		network.Connect("reward", "rewarded", gracious.ModulationPort),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := network.Connect("rewarded", "main", gracious.ModulationPort); !errors.Is(err, gracious.ErrNoModulationPort) {
+		t.Errorf("expected ErrNoModulationPort, got %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		association = a
+		network.Step()
+		association = b
+		network.Step()
+	}
+	g.Freeze()
+	if actual := g.Evoke(neutral, a); actual.HammingDistance(goSignal) != 0 {
+		t.Errorf("expected the rewarded pairing to be learnt, got %s", actual.Represent())
+	}
+	if actual := g.Evoke(neutral, b); len(actual.Features) != 0 {
+		t.Errorf("expected the unrewarded pairing not to be learnt, got %s", actual.Represent())
+	}
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"errors"
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"testing"
+)
+
+// recorder is an Output which records every signal it is actuated with
+type recorder struct {
+	id      string
+	signals []gracious.QualitativeSignal
+}
+
+func (r *recorder) GetId() string {
+	return r.id
+}
+
+func (r *recorder) Actuate(signal gracious.QualitativeSignal) {
+	r.signals = append(r.signals, signal)
+}
+
+func TestNetwork(t *testing.T) {
+	colorJSA := io.JsonFromFileName("data/colorA.json")
+	wordJSA := io.JsonFromFileName("data/wordA.json")
+	colorName, wordName := "", ""
+	colors := io.NewFunctionalSensor("colors", func() gracious.QualitativeSignal {
+		return colorJSA.GetJsonSignalById(colorName).ToDistributedSignal()
+	})
+	words := io.NewFunctionalSensor("words", func() gracious.QualitativeSignal {
+		return wordJSA.GetJsonSignalById(wordName).ToDistributedSignal()
+	})
+	bg := gracious.NewBasicGroup("colorGroup")
+	bg.CorrelationThreshold = 5
+	speech := &recorder{id: "speech"}
+	echo := &recorder{id: "echo"}
+	network := gracious.NewNetwork("network")
+	for _, err := range []error{
+		network.AddOutput(speech),
+		network.AddOutput(echo),
+		network.AddGroup(bg),
+		network.AddSource(colors),
+		network.AddSource(words),
+		network.Connect("colors", "colorGroup", gracious.MainPort),
+		network.Connect("words", "colorGroup", gracious.AssociationPort),
+		network.Connect("colorGroup", "speech", gracious.MainPort),
+		network.Feedback("colorGroup", "echo", gracious.MainPort),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := network.Connect("speech", "colorGroup", gracious.AssociationPort); !errors.Is(err, gracious.ErrCycle) {
+		t.Errorf("expected ErrCycle, got %v", err)
+	}
+	if err := network.Connect("colors", "missing", gracious.MainPort); !errors.Is(err, gracious.ErrUnknownNode) {
+		t.Errorf("expected ErrUnknownNode, got %v", err)
+	}
+	for _, name := range colorNames {
+		colorName, wordName = name, name
+		for i := 0; i < 6; i++ {
+			network.Step()
+		}
+	}
+	colorName = ""
+	for _, name := range colorNames {
+		wordName = name
+		network.Step()
+		expected := bg.GetFirePattern()
+		recalled := speech.signals[len(speech.signals)-1]
+		if len(recalled.Features) == 0 || !sameFeatures(expected, recalled) {
+			t.Errorf("%s: expected %s to be spoken, got %s", name, expected.Represent(), recalled.Represent())
+		}
+	}
+	last := len(speech.signals) - 1
+	if len(echo.signals) != len(speech.signals) || !sameFeatures(echo.signals[last], speech.signals[last-1]) {
+		t.Errorf("expected the feedback edge to carry the output of the previous step")
+	}
+}
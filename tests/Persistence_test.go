@@ -0,0 +1,190 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"testing"
+)
+
+var colorNames = []string{"red", "green", "blue", "yellow", "cyan", "magenta"}
+
+// train presents each named color and word pair to the Group for the given number of iterations
+func train(g gracious.Group, colors, words io.JsonSignalArray, iterations int) {
+	for _, name := range colorNames {
+		color := colors.GetJsonSignalById(name).ToDistributedSignal()
+		word := words.GetJsonSignalById(name).ToDistributedSignal()
+		for i := 0; i < iterations; i++ {
+			g.Evoke(color, word)
+		}
+	}
+}
+
+// sameFeatures reports whether both signals have exactly the same set of features
+func sameFeatures(a, b gracious.QualitativeSignal) bool {
+	if len(a.Features) != len(b.Features) {
+		return false
+	}
+	for addr, feature := range a.Features {
+		if b.Features[addr] != feature {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBasicGroupSaveLoad(t *testing.T) {
+	colorJSA := io.JsonFromFileName("data/colorA.json")
+	wordJSA := io.JsonFromFileName("data/wordA.json")
+	bg := gracious.NewBasicGroup("persistedGroup")
+	bg.CorrelationThreshold = 5
+	train(bg, colorJSA, wordJSA, 6)
+	var buffer bytes.Buffer
+	if err := bg.Save(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := gracious.LoadBasicGroup(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.GetId() != bg.GetId() || loaded.CorrelationThreshold != bg.CorrelationThreshold {
+		t.Errorf("loaded group settings differ from the saved group")
+	}
+	for _, name := range colorNames {
+		word := wordJSA.GetJsonSignalById(name).ToDistributedSignal()
+		expected := bg.Evoke(gracious.NewQualitativeSignal("void"), word)
+		actual := loaded.Evoke(gracious.NewQualitativeSignal("void"), word)
+		if !sameFeatures(expected, actual) {
+			t.Errorf("%s: expected %s, got %s", name, expected.Represent(), actual.Represent())
+		}
+	}
+}
+
+func TestAdvancedGroupSaveLoad(t *testing.T) {
+	colorJSA := io.JsonFromFileName("data/colorB.json")
+	wordJSA := io.JsonFromFileName("data/wordA.json")
+	ag := gracious.NewAdvancedGroup("persistedAdvancedGroup")
+	ag.CorrelationThreshold = 5
+	ag.GrdCorrelationThreshold = 3
+	train(ag, colorJSA, wordJSA, 12)
+	var buffer bytes.Buffer
+	if err := ag.Save(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := gracious.LoadAdvancedGroup(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.GrdCorrelationThreshold != ag.GrdCorrelationThreshold {
+		t.Errorf("loaded grandmother threshold differs from the saved group")
+	}
+	for _, name := range colorNames {
+		word := wordJSA.GetJsonSignalById(name).ToDistributedSignal()
+		expected := ag.Evoke(gracious.NewQualitativeSignal("void"), word)
+		actual := loaded.Evoke(gracious.NewQualitativeSignal("void"), word)
+		if !sameFeatures(expected, actual) {
+			t.Errorf("%s: expected %s, got %s", name, expected.Represent(), actual.Represent())
+		}
+	}
+}
+
+func TestLoadAdvancedGroupWithoutGrandmothers(t *testing.T) {
+	_, err := gracious.LoadAdvancedGroup(bytes.NewBufferString(`{"id": "empty", "grdNeurons": []}`))
+	if err != gracious.ErrNoGrandmotherNeurons {
+		t.Errorf("expected ErrNoGrandmotherNeurons, got %v", err)
+	}
+}
+
+func TestLoadDispatchesOnGroupType(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := gracious.NewAdvancedGroup("tagged").Save(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	saved := buffer.String()
+	g, err := gracious.Load(bytes.NewBufferString(saved))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := g.(*gracious.AdvancedGroup); !ok {
+		t.Errorf("expected an AdvancedGroup, got %T", g)
+	}
+	if _, err := gracious.LoadBasicGroup(bytes.NewBufferString(saved)); !errors.Is(err, gracious.ErrUnexpectedGroupType) {
+		t.Errorf("expected ErrUnexpectedGroupType, got %v", err)
+	}
+}
+
+func TestLoadUnversionedSnapshot(t *testing.T) {
+	legacy := `{"id": "legacy", "correlationThreshold": 5, "neurons": [{"address": {"X": 0, "Y": 1}, ` +
+		`"synapses": [{"address": {"X": 0, "Y": 2}, "correlationSum": 8, "weightValue": 1}], "learningEnabled": true}]}`
+	bg, err := gracious.LoadBasicGroup(bytes.NewBufferString(legacy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	association := gracious.NewQualitativeSignal("association")
+	association.Features[gracious.Address{X: 0, Y: 2}] = 1
+	evocation := bg.Evoke(gracious.NewQualitativeSignal("void"), association)
+	if evocation.Features[gracious.Address{X: 0, Y: 1}] == 0 {
+		t.Errorf("expected the legacy association to evoke, got %s", evocation.Represent())
+	}
+}
+
+func TestLoadFutureSnapshot(t *testing.T) {
+	future := fmt.Sprintf(`{"format": "gracious", "schemaVersion": %d, "type": "BasicGroup", "group": {}}`,
+		gracious.SchemaVersion+1)
+	if _, err := gracious.Load(bytes.NewBufferString(future)); !errors.Is(err, gracious.ErrFutureSchemaVersion) {
+		t.Errorf("expected ErrFutureSchemaVersion, got %v", err)
+	}
+}
+
+func TestLoadUnknownSchemaVersion(t *testing.T) {
+	unknown := `{"format": "gracious", "schemaVersion": -1, "type": "BasicGroup", "group": {}}`
+	if _, err := gracious.Load(bytes.NewBufferString(unknown)); !errors.Is(err, gracious.ErrUnknownSchemaVersion) {
+		t.Errorf("expected ErrUnknownSchemaVersion, got %v", err)
+	}
+}
+
+// TestMigrationChain loads the same group from a snapshot of every earlier schema version, which must be migrated
+// through every following version to load.
+func TestMigrationChain(t *testing.T) {
+	group := `{"id": "migrated", "correlationThreshold": 5, "neurons": [{"address": {"X": 0, "Y": 1}, ` +
+		`"synapses": [{"address": {"X": 0, "Y": 2}, "correlationSum": 8, "weightValue": 1}], "learningEnabled": true}]}`
+	association := gracious.NewQualitativeSignal("association")
+	association.Features[gracious.Address{X: 0, Y: 2}] = 1
+	for version := 0; version < gracious.SchemaVersion; version++ {
+		snapshot := fmt.Sprintf(`{"format": "gracious", "schemaVersion": %d, "type": "BasicGroup", "group": %s}`,
+			version, group)
+		bg, err := gracious.LoadBasicGroup(bytes.NewBufferString(snapshot))
+		if err != nil {
+			t.Fatalf("version %d: %v", version, err)
+		}
+		evocation := bg.Evoke(gracious.NewQualitativeSignal("void"), association)
+		if evocation.Features[gracious.Address{X: 0, Y: 1}] == 0 {
+			t.Errorf("version %d: expected the migrated association to evoke, got %s", version, evocation.Represent())
+		}
+	}
+}
+
+func TestSnapshotContainer(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := gracious.NewBasicGroup("stamped").Save(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	var c struct {
+		Format        string `json:"format"`
+		SchemaVersion int    `json:"schemaVersion"`
+		ModuleVersion string `json:"moduleVersion"`
+		Type          string `json:"type"`
+	}
+	if err := json.Unmarshal(buffer.Bytes(), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Format != "gracious" || c.SchemaVersion != gracious.SchemaVersion || c.Type != gracious.BasicGroupType {
+		t.Errorf("unexpected container %+v", c)
+	}
+	if c.ModuleVersion == "" {
+		t.Errorf("expected the snapshot to be stamped with the module version")
+	}
+}
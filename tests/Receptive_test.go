@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"github.com/Art-of-the-Living/gracious"
+	"testing"
+)
+
+// bar returns a signal with a bar of the given length starting at the Address, running along X when horizontal and
+// along Y otherwise.
+func bar(name string, start gracious.Address, length int, horizontal bool) gracious.QualitativeSignal {
+	q := gracious.NewQualitativeSignal(name)
+	for i := 0; i < length; i++ {
+		if horizontal {
+			q.Features[gracious.Address{X: start.X + i, Y: start.Y}] = 1
+		} else {
+			q.Features[gracious.Address{X: start.X, Y: start.Y + i}] = 1
+		}
+	}
+	return q
+}
+
+func TestReceptiveField(t *testing.T) {
+	field := gracious.NewReceptiveField("edges")
+	field.Threshold = 2
+	horizontal := field.AddKernel(map[gracious.Address]int{{X: 0}: 1, {X: 1}: 1})
+	vertical := field.Learn(bar("exemplar", gracious.Address{X: 7, Y: 3}, 2, false))
+	if field.Kernels() != 2 || horizontal != 0 || vertical != 1 {
+		t.Fatalf("expected two kernels, got %d", field.Kernels())
+	}
+	for _, c := range []struct {
+		name     string
+		input    gracious.QualitativeSignal
+		expected gracious.QualitativeSignal
+	}{
+		{"Horizontal", bar("", gracious.Address{X: 3, Y: 2}, 2, true),
+			gridOf("", map[gracious.Address]int{{X: 3, Y: 2, Z: horizontal}: 2})},
+		{"Vertical", bar("", gracious.Address{X: 3, Y: 2}, 2, false),
+			gridOf("", map[gracious.Address]int{{X: 3, Y: 2, Z: vertical}: 2})},
+		{"Long", bar("", gracious.Address{X: -1, Y: 0}, 3, true),
+			gridOf("", map[gracious.Address]int{{X: -1, Z: horizontal}: 2, {X: 0, Z: horizontal}: 2})},
+		{"Dot", gridOf("", map[gracious.Address]int{{X: 3, Y: 2}: 1}), gridOf("", map[gracious.Address]int{})},
+	} {
+		if actual := field.Evoke(c.input); !sameFeatures(c.expected, actual) {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected.Represent(), actual.Represent())
+		}
+	}
+
+	// Pooling detects a bar anywhere within the same block at the same Address
+	field.Pool = 8
+	a := field.Evoke(bar("", gracious.Address{X: 1, Y: 1}, 2, true))
+	b := field.Evoke(bar("", gracious.Address{X: 5, Y: 6}, 2, true))
+	expected := gridOf("", map[gracious.Address]int{{Z: horizontal}: 2})
+	if !sameFeatures(expected, a) || !sameFeatures(expected, b) {
+		t.Errorf("expected both bars to be detected as %s, got %s and %s", expected.Represent(), a.Represent(), b.Represent())
+	}
+}
+
+// TestReceptiveFieldRecognition stacks a pooling ReceptiveField in front of a BasicGroup, which learns the name of a
+// bar at one position and recalls it for the same bar at another.
+func TestReceptiveFieldRecognition(t *testing.T) {
+	field := gracious.NewReceptiveField("edges")
+	field.Threshold = 2
+	field.Pool = 16
+	field.AddKernel(map[gracious.Address]int{{X: 0}: 1, {X: 1}: 1})
+	field.AddKernel(map[gracious.Address]int{{Y: 0}: 1, {Y: 1}: 1})
+	names := gracious.NewBasicGroup("names")
+	names.WTA = -1
+	horizontal, vertical := signalOf("horizontal", map[int]int{0: 1}), signalOf("vertical", map[int]int{1: 1})
+	for i := 0; i < 5; i++ {
+		names.Evoke(horizontal, field.Evoke(bar("", gracious.Address{X: 2, Y: 2}, 2, true)))
+		names.Evoke(vertical, field.Evoke(bar("", gracious.Address{X: 2, Y: 2}, 2, false)))
+	}
+	silence := gracious.NewQualitativeSignal("silence")
+	actual := names.Evoke(silence, field.Evoke(bar("", gracious.Address{X: 9, Y: 12}, 2, true)))
+	if actual.HammingDistance(horizontal) != 0 {
+		t.Errorf("expected the moved bar to be recognized as %s, got %s", horizontal.Represent(), actual.Represent())
+	}
+	actual = names.Evoke(silence, field.Evoke(bar("", gracious.Address{X: 12, Y: 4}, 2, false)))
+	if actual.HammingDistance(vertical) != 0 {
+		t.Errorf("expected the moved bar to be recognized as %s, got %s", vertical.Represent(), actual.Represent())
+	}
+}
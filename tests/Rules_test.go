@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"github.com/Art-of-the-Living/gracious"
+	"testing"
+)
+
+var rules = map[string]gracious.LearningRule{
+	"CorrelativeHebbian": gracious.CorrelativeHebbian{},
+	"ClassicalHebb":      gracious.ClassicalHebb{},
+	"Oja":                gracious.Oja{Normalization: 2},
+	"Covariance":         gracious.Covariance{},
+	"BCM":                gracious.BCM{},
+}
+
+// TestLearningRules teaches a group with every rule that one feature means go and another means stop.
+func TestLearningRules(t *testing.T) {
+	a, b := signalOf("a", map[int]int{5: 1}), signalOf("b", map[int]int{6: 1})
+	silence := gracious.NewQualitativeSignal("silence")
+	for name, rule := range rules {
+		g := gracious.NewBasicGroup(name)
+		g.WTA = -1
+		g.LearningRule = rule
+		for i := 0; i < 10; i++ {
+			g.Evoke(goSignal, a)
+			g.Evoke(stopSignal, b)
+		}
+		if actual := g.Evoke(silence, a); actual.HammingDistance(goSignal) != 0 {
+			t.Errorf("%s: expected a to evoke go, got %s", name, actual.Represent())
+		}
+		if actual := g.Evoke(silence, b); actual.HammingDistance(stopSignal) != 0 {
+			t.Errorf("%s: expected b to evoke stop, got %s", name, actual.Represent())
+		}
+	}
+}
+
+// strengthAfter returns the graded strength with which a feature recalls go after the pairing is repeated.
+func strengthAfter(rule gracious.LearningRule, repetitions int) int {
+	feature := signalOf("feature", map[int]int{5: 1})
+	g := gracious.NewBasicGroup("graded")
+	g.WTA = -1
+	g.WeightCap = 100
+	g.LearningRule = rule
+	for i := 0; i < repetitions; i++ {
+		g.Evoke(goSignal, feature)
+	}
+	return g.Evoke(gracious.NewQualitativeSignal("silence"), feature).Features[gracious.Address{X: 0}]
+}
+
+// TestBoundedLearning checks that the normalized and sliding threshold rules stop strengthening a synapse with
+// repetition, while classical Hebbian learning keeps growing.
+func TestBoundedLearning(t *testing.T) {
+	early, late := strengthAfter(gracious.ClassicalHebb{}, 40), strengthAfter(gracious.ClassicalHebb{}, 80)
+	if late <= early {
+		t.Errorf("expected classical Hebbian learning to keep growing, got %d then %d", early, late)
+	}
+	for _, name := range []string{"Oja", "BCM"} {
+		early, late := strengthAfter(rules[name], 40), strengthAfter(rules[name], 80)
+		if early < 1 || late != early {
+			t.Errorf("%s: expected the strength to settle, got %d then %d", name, early, late)
+		}
+	}
+	if strength := strengthAfter(gracious.Oja{Normalization: 2}, 40); strength != 3 {
+		t.Errorf("expected Oja to settle at a correlation of 4 * Normalization, got a strength of %d", strength)
+	}
+}
+
+// customRule is a LearningRule unknown to Gracious.
+type customRule struct{}
+
+func (customRule) Learn(syn *gracious.Synapse, a gracious.Activity) {}
+
+func (customRule) TrainsInactive() bool { return false }
+
+func TestLearningRulePersistence(t *testing.T) {
+	for name, rule := range rules {
+		g := gracious.NewBasicGroup(name)
+		g.LearningRule = rule
+		g.Evoke(goSignal, greenShape)
+		var buffer bytes.Buffer
+		if err := g.Save(&buffer); err != nil {
+			t.Fatal(err)
+		}
+		restored, err := gracious.LoadBasicGroup(&buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if restored.LearningRule != rule {
+			t.Errorf("%s: expected the rule to be restored, got %#v", name, restored.LearningRule)
+		}
+	}
+	g := gracious.NewBasicGroup("custom")
+	g.LearningRule = customRule{}
+	var buffer bytes.Buffer
+	if err := g.Save(&buffer); !errors.Is(err, gracious.ErrUnsupportedLearningRule) {
+		t.Errorf("expected ErrUnsupportedLearningRule, got %v", err)
+	}
+}
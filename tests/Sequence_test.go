@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"bytes"
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/tests/tools"
+	"testing"
+)
+
+// letter returns the Address the TextReader uses for an upper case letter
+func letter(char byte) gracious.Address {
+	return gracious.Address{X: 0, Y: int(char) - 65}
+}
+
+// recallSequence presents the first letter of the text to the SequenceGroup and returns the letters it evokes on
+// each of the following evocations.
+func recallSequence(sg *gracious.SequenceGroup, text string) []gracious.QualitativeSignal {
+	sg.Reset()
+	reader := tools.NewTextReader(text[:1])
+	sg.Evoke(reader.Evoke(), gracious.NewQualitativeSignal("void"))
+	recalls := make([]gracious.QualitativeSignal, 0, len(text)-1)
+	for i := 1; i < len(text); i++ {
+		recalls = append(recalls, sg.Evoke(gracious.NewQualitativeSignal("void"), gracious.NewQualitativeSignal("void")))
+	}
+	return recalls
+}
+
+func TestSequenceGroup(t *testing.T) {
+	sg := gracious.NewSequenceGroup("sequenceGroup")
+	sg.CorrelationThreshold = 2
+	reader := tools.NewTextReader("RED")
+	for i := 0; i < 4; i++ {
+		sg.Reset()
+		reader.Reset()
+		for reader.Next() {
+			sg.Evoke(reader.Evoke(), gracious.NewQualitativeSignal("void"))
+		}
+	}
+	recalls := recallSequence(sg, "RED")
+	for i, recall := range recalls {
+		expected := "RED"[i+1]
+		if len(recall.Features) != 1 || recall.Features[letter(expected)] == 0 {
+			t.Errorf("expected %c to be evoked, got %s", expected, recall.Represent())
+		}
+	}
+	var buffer bytes.Buffer
+	if err := sg.Save(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := gracious.LoadSequenceGroup(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, recall := range recallSequence(loaded, "RED") {
+		if !sameFeatures(recalls[i], recall) {
+			t.Errorf("loaded group recalled %s, expected %s", recall.Represent(), recalls[i].Represent())
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"bytes"
+	"github.com/Art-of-the-Living/gracious"
+	"github.com/Art-of-the-Living/gracious/io"
+	"testing"
+)
+
+// The percepts of an agent, and the outcomes they are experienced with.
+var (
+	fire  = signalOf("fire", map[int]int{5: 1})
+	food  = signalOf("food", map[int]int{6: 1})
+	grass = signalOf("grass", map[int]int{7: 1})
+)
+
+// experience returns a ValenceGroup which has learnt that fire is painful and food is pleasant.
+func experience() *gracious.ValenceGroup {
+	g := gracious.NewValenceGroup("valence")
+	for i := 0; i < 5; i++ {
+		g.Evoke(pain, fire)
+		g.Evoke(pleasure, food)
+	}
+	return g
+}
+
+func TestValenceGroup(t *testing.T) {
+	g := experience()
+	if valence := g.Appraise(fire); valence >= 0 {
+		t.Errorf("expected fire to be painful, got %d", valence)
+	}
+	if valence := g.Appraise(food); valence <= 0 {
+		t.Errorf("expected food to be pleasant, got %d", valence)
+	}
+	if valence := g.Appraise(grass); valence != 0 {
+		t.Errorf("expected grass to be insignificant, got %d", valence)
+	}
+	if actual := g.Evoke(neutral, fire); actual.Features[gracious.Address{X: 1}] >= 0 {
+		t.Errorf("expected fire to evoke pain, got %s", actual.Represent())
+	}
+	for i := 0; i < 5; i++ {
+		g.Evoke(pain, food)
+	}
+	if valence := g.Appraise(food); valence >= 0 {
+		t.Errorf("expected spoilt food to be appraised as painful, got %d", valence)
+	}
+}
+
+func TestPrioritize(t *testing.T) {
+	g := experience()
+	prioritized := g.Prioritize([]gracious.QualitativeSignal{grass, food, fire})
+	for i, expected := range []gracious.QualitativeSignal{food, fire, grass} {
+		if prioritized[i].Id != expected.Id {
+			t.Errorf("expected %s at position %d, got %s", expected.Id, i, prioritized[i].Id)
+		}
+	}
+}
+
+func TestValenceGroupPersistence(t *testing.T) {
+	g := experience()
+	var buffer bytes.Buffer
+	if err := g.Save(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := gracious.Load(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, ok := loaded.(*gracious.ValenceGroup)
+	if !ok {
+		t.Fatalf("expected a ValenceGroup, got %T", loaded)
+	}
+	for _, percept := range []gracious.QualitativeSignal{fire, food, grass} {
+		if expected, actual := g.Appraise(percept), restored.Appraise(percept); actual != expected {
+			t.Errorf("%s: expected the restored valence %d, got %d", percept.Id, expected, actual)
+		}
+	}
+}
+
+// TestLearntReinforcement wires a trained ValenceGroup into the ModulationPort of a group, which learns to go for
+// the percepts that are appraised as pleasant.
+func TestLearntReinforcement(t *testing.T) {
+	valence := experience()
+	valence.Freeze()
+	percept := food
+	perception := io.NewFunctionalSensor("perception", func() gracious.QualitativeSignal { return percept })
+	action := io.NewFunctionalSensor("action", func() gracious.QualitativeSignal { return goSignal })
+	g := gracious.NewBasicGroup("approach")
+	g.WTA = -1
+	network := gracious.NewNetwork("network")
+	for _, err := range []error{
+		network.AddGroup(valence),
+		network.AddGroup(g),
+		network.AddSource(perception),
+		network.AddSource(action),
+		network.Connect("perception", "valence", gracious.AssociationPort),
+		network.Connect("perception", "approach", gracious.AssociationPort),
+		network.Connect("action", "approach", gracious.MainPort),
+		network.Connect("valence", "approach", gracious.ModulationPort),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		percept = food
+		network.Step()
+		percept = fire
+		network.Step()
+	}
+	g.Freeze()
+	if actual := g.Evoke(neutral, food); actual.HammingDistance(goSignal) != 0 {
+		t.Errorf("expected to approach food, got %s", actual.Represent())
+	}
+	if actual := g.Evoke(neutral, fire); len(actual.Features) != 0 {
+		t.Errorf("expected not to approach fire, got %s", actual.Represent())
+	}
+}
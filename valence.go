@@ -0,0 +1,119 @@
+package gracious
+
+import (
+	"sort"
+	"sync"
+)
+
+// The Addresses of the pleasure and pain neurons of a ValenceGroup.
+var (
+	pleasureAddress = Address{X: 0}
+	painAddress     = Address{X: 1}
+)
+
+// A ValenceGroup attaches an emotional significance to percepts. It learns to associate percepts with the pleasure or
+// pain they were experienced with, and evokes that state again when a percept reappears, so that a percept which was
+// once followed by pain is recognized as painful before the pain arrives.
+//
+// The main signal of a ValenceGroup is the outcome, read in the same way as a modulation signal: the sum of its
+// features is the valence, positive for pleasure and negative for pain, and an outcome without features teaches
+// nothing. The association signal is the percept. The returned signal holds the evoked pleasure as a positive feature
+// at X 0 and the evoked pain as a negative feature at X 1, so the sum of its features is the learnt valence of the
+// percept and the signal can be wired into the ModulationPort of another Group to reinforce it with learnt outcomes.
+// The firing pattern of the component BasicGroup holds the strength of both neurons as positive features.
+//
+// A ValenceGroup inhibits and unlearns on mismatch by default, so that a percept whose outcome changes is appraised
+// anew.
+type ValenceGroup struct {
+	*BasicGroup // The component BasicGroup, with a pleasure and a pain neuron
+}
+
+// NewValenceGroup returns a new ValenceGroup instance which has learnt no valence.
+func NewValenceGroup(id string) *ValenceGroup {
+	g := ValenceGroup{BasicGroup: NewBasicGroup(id)}
+	g.WTA = -1
+	g.MismatchInhibition = true
+	g.UnlearnOnMismatch = true
+	return &g
+}
+
+// Evoke will test the ValenceGroup for the valence of the percept, the association signal, while learning the
+// valence of the outcome, the main signal. Concurrent calls to Evoke are serialized.
+func (g *ValenceGroup) Evoke(main, association QualitativeSignal) QualitativeSignal {
+	return g.valence(g.BasicGroup.evokeAssociation(g.outcome(main), association, 1))
+}
+
+// EvokeDense behaves exactly as Evoke, but takes the percept as a DenseSignal.
+func (g *ValenceGroup) EvokeDense(main QualitativeSignal, association DenseSignal) QualitativeSignal {
+	return g.valence(g.BasicGroup.EvokeDense(g.outcome(main), association))
+}
+
+// EvokeGated behaves exactly as Evoke, but the ValenceGroup only learns when the gate signal holds a positive feature.
+func (g *ValenceGroup) EvokeGated(main, association, gate QualitativeSignal) QualitativeSignal {
+	return g.valence(g.BasicGroup.evokeAssociation(g.outcome(main), association, gateModulation(gate)))
+}
+
+// EvokeModulated behaves exactly as Evoke, but the training of the ValenceGroup is modulated as by
+// BasicGroup.EvokeModulated.
+func (g *ValenceGroup) EvokeModulated(main, association, modulation QualitativeSignal) QualitativeSignal {
+	return g.valence(g.BasicGroup.evokeAssociation(g.outcome(main), association, modulationLevel(modulation)))
+}
+
+// AsyncEvoke will Evoke this Group as a member of a WaitGroup
+func (g *ValenceGroup) AsyncEvoke(main, association QualitativeSignal, wg *sync.WaitGroup) QualitativeSignal {
+	defer wg.Done()
+	return g.Evoke(main, association)
+}
+
+// Appraise returns the learnt valence of the percept: positive if it evokes pleasure, negative if it evokes pain and
+// 0 if it is not significant. The ValenceGroup is evoked without learning.
+func (g *ValenceGroup) Appraise(percept QualitativeSignal) int {
+	outcome := NewQualitativeSignal(g.id + "-appraisal")
+	return modulationLevel(g.valence(g.BasicGroup.evokeAssociation(outcome, percept, 0)))
+}
+
+// Prioritize returns the percepts ordered by their significance, the strength of their learnt valence whether pleasant
+// or painful, so that attention can be paid to the most significant percept first. Percepts of equal significance
+// keep their order. The percepts are appraised without learning.
+func (g *ValenceGroup) Prioritize(percepts []QualitativeSignal) []QualitativeSignal {
+	significance := make([]int, len(percepts))
+	order := make([]int, len(percepts))
+	for i, percept := range percepts {
+		if significance[i] = g.Appraise(percept); significance[i] < 0 {
+			significance[i] = -significance[i]
+		}
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return significance[order[i]] > significance[order[j]]
+	})
+	prioritized := make([]QualitativeSignal, len(percepts))
+	for i, index := range order {
+		prioritized[i] = percepts[index]
+	}
+	return prioritized
+}
+
+// outcome returns the main signal of the component BasicGroup for the outcome: the valence on the pleasure or the
+// pain neuron.
+func (g *ValenceGroup) outcome(main QualitativeSignal) QualitativeSignal {
+	outcome := NewQualitativeSignal(main.Id)
+	if level := modulationLevel(main); level > 0 {
+		outcome.Features[pleasureAddress] = level
+	} else if level < 0 {
+		outcome.Features[painAddress] = -level
+	}
+	return outcome
+}
+
+// valence returns the evoked valence for the firing pattern of the component BasicGroup, with pain negative.
+func (g *ValenceGroup) valence(pattern QualitativeSignal) QualitativeSignal {
+	valence := NewQualitativeSignal(g.id + "-valence")
+	if pleasure := pattern.Features[pleasureAddress]; pleasure > 0 {
+		valence.Features[pleasureAddress] = pleasure
+	}
+	if pain := pattern.Features[painAddress]; pain > 0 {
+		valence.Features[painAddress] = -pain
+	}
+	return valence
+}